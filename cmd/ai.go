@@ -0,0 +1,217 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openai/openai-go/v3"
+	"github.com/openai/openai-go/v3/option"
+	"github.com/spf13/viper"
+
+	"github.com/aixoio/tt/secrets"
+	"github.com/aixoio/tt/styles"
+)
+
+// AIRequest is a single chat completion made on behalf of a tt command
+type AIRequest struct {
+	APIKey  string
+	BaseURL string
+	Model   string
+	Prompt  string
+	// Cacheable requests are answered from the on-disk cache when the same
+	// model, base URL and prompt were sent before
+	Cacheable bool
+	// Refresh skips the cache lookup but still stores the new answer, for
+	// "give me another one" actions
+	Refresh bool
+	// Prechecked requests were already redacted and checked against the
+	// budget by the caller, as when several are sent at once
+	Prechecked bool
+	// Temperature overrides the model's default sampling temperature when set
+	Temperature float64
+}
+
+// cacheKey covers everything in the request that changes the answer
+func (req AIRequest) cacheKey() string {
+	return cacheKey(req.BaseURL, req.Model, strconv.FormatFloat(req.Temperature, 'g', -1, 64), req.Prompt)
+}
+
+// aiCommand is the command path making AI requests, recorded in the usage ledger
+var aiCommand = "tt"
+
+// aiComplete sends a request through the cache, budget check and usage ledger
+func aiComplete(req AIRequest) (string, error) {
+	if req.Model == "" {
+		req.Model = viper.GetString("default_model")
+	}
+	if req.BaseURL == "" {
+		req.BaseURL = viper.GetString("base_url")
+	}
+
+	if !req.Prechecked {
+		req.Prompt = redactForAI(req.Prompt)
+	}
+
+	useCache := req.Cacheable && viper.GetBool("ai_cache")
+	key := req.cacheKey()
+	if useCache && !req.Refresh {
+		if answer, found := readCache("ai", key); found {
+			recordUsage(UsageEntry{Model: req.Model, Cached: true})
+			return answer, nil
+		}
+	}
+
+	if !req.Prechecked {
+		if err := checkBudget(); err != nil {
+			return "", err
+		}
+	}
+
+	client := openai.NewClient(
+		option.WithBaseURL(req.BaseURL),
+		option.WithHeader("HTTP-Referer", "https://github.com/aixoio/tt"),
+		option.WithHeader("X-Title", "tt"),
+		option.WithAPIKey(req.APIKey),
+	)
+
+	params := openai.ChatCompletionNewParams{
+		Model:    req.Model,
+		Messages: []openai.ChatCompletionMessageParamUnion{openai.UserMessage(req.Prompt)},
+	}
+	if req.Temperature > 0 {
+		params.Temperature = openai.Float(req.Temperature)
+	}
+	resp, err := client.Chat.Completions.New(context.Background(), params)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate AI response: %w", err)
+	}
+
+	entry := UsageEntry{
+		Model:            req.Model,
+		PromptTokens:     resp.Usage.PromptTokens,
+		CompletionTokens: resp.Usage.CompletionTokens,
+	}
+	entry.Cost = modelCost(req.Model, entry.PromptTokens, entry.CompletionTokens)
+	recordUsage(entry)
+
+	if len(resp.Choices) == 0 {
+		return "", fmt.Errorf("no response from AI model")
+	}
+	answer := strings.TrimSpace(resp.Choices[0].Message.Content)
+
+	if useCache && answer != "" {
+		// A failed cache write only costs a future request
+		_ = writeCache("ai", key, answer)
+	}
+	return answer, nil
+}
+
+// aiRedactor builds the redactor from the redact config, or returns nil when
+// redaction is turned off. Invalid patterns fall back to the built-in rules.
+func aiRedactor() *secrets.Redactor {
+	if !viper.GetBool("redact.enabled") {
+		return nil
+	}
+	redactor, err := secrets.New(viper.GetStringSlice("redact.patterns"), viper.GetStringSlice("redact.paths"))
+	if err != nil {
+		printNotice(styles.WarningIcon + " " + styles.Warning.Render("Ignoring redact config: "+err.Error()))
+		redactor, _ = secrets.New(nil, nil)
+	}
+	return redactor
+}
+
+// redactForAI removes secrets from text bound for the model and shows what was removed
+func redactForAI(text string) string {
+	redactor := aiRedactor()
+	if redactor == nil {
+		return text
+	}
+	redacted, findings := redactor.Redact(text)
+	printRedactions(findings)
+	return redacted
+}
+
+// printRedactions lists what was kept back from the model. It runs while
+// spinners are shown and before --json output, so it writes to stderr.
+func printRedactions(findings []secrets.Finding) {
+	if len(findings) == 0 {
+		return
+	}
+	printNotice(styles.WarningIcon + " " + styles.Warning.Render(fmt.Sprintf("Redacted %d item(s) before sending to the model:", len(findings))))
+	for _, finding := range findings {
+		location := finding.Location()
+		if location == "" {
+			location = "prompt"
+		}
+		line := "  • " + styles.FilePath.Render(location) + " " + styles.Neutral.Render(finding.Rule)
+		if masked := finding.Masked(); masked != "" {
+			line += " " + styles.Muted.Render(masked)
+		}
+		printNotice(line)
+	}
+}
+
+// ModelPrice is the configured price of a model in dollars per million tokens
+type ModelPrice struct {
+	Model  string  `mapstructure:"model"`
+	Input  float64 `mapstructure:"input"`
+	Output float64 `mapstructure:"output"`
+}
+
+// modelCost prices a completion using the model_prices config list. Models
+// without a price cost 0 so usage is still counted in tokens.
+func modelCost(model string, promptTokens, completionTokens int64) float64 {
+	var prices []ModelPrice
+	if err := viper.UnmarshalKey("model_prices", &prices); err != nil {
+		return 0
+	}
+	for _, price := range prices {
+		if price.Model == model {
+			return (float64(promptTokens)*price.Input + float64(completionTokens)*price.Output) / 1_000_000
+		}
+	}
+	return 0
+}
+
+// checkBudget compares this month's spend with monthly_budget. With
+// budget_action set to block it refuses the request, otherwise it warns.
+// Both go to stderr so they never mix with output meant for other programs.
+func checkBudget() error {
+	budget := viper.GetFloat64("monthly_budget")
+	if budget <= 0 {
+		return nil
+	}
+
+	entries, err := readUsage()
+	if err != nil {
+		return nil
+	}
+	now := time.Now()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	spent := 0.0
+	for _, entry := range entries {
+		if !entry.Time.Before(monthStart) {
+			spent += entry.Cost
+		}
+	}
+	if spent < budget {
+		return nil
+	}
+
+	message := "Monthly AI budget of $" + strconv.FormatFloat(budget, 'f', 2, 64) + " reached ($" + strconv.FormatFloat(spent, 'f', 2, 64) + " spent)"
+	if viper.GetString("budget_action") == "block" {
+		printNotice(styles.ErrorIcon + " " + styles.Error.Render(message+". Raise monthly_budget or set budget_action to warn."))
+		return fmt.Errorf("monthly AI budget reached")
+	}
+	printNotice(styles.WarningIcon + " " + styles.Warning.Render(message))
+	return nil
+}
+
+// generateAIResponse makes a cacheable request for commands that ask for a
+// summary, review or notes of fixed input
+func generateAIResponse(apiKey, baseURL, model, prompt string) (string, error) {
+	return aiComplete(AIRequest{APIKey: apiKey, BaseURL: baseURL, Model: model, Prompt: prompt, Cacheable: true})
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestAICompleteCacheKey(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"id": "1", "object": "chat.completion", "model": "m", "choices": [{"index": 0, "finish_reason": "stop", "message": {"role": "assistant", "content": "answer %d"}}]}`, n)
+	}))
+	defer server.Close()
+
+	t.Setenv("HOME", t.TempDir())
+	viper.Set("ai_cache", true)
+	t.Cleanup(func() { viper.Set("ai_cache", nil) })
+
+	req := AIRequest{APIKey: "test", BaseURL: server.URL, Model: "m", Prompt: "hello", Cacheable: true}
+	complete := func(req AIRequest) string {
+		answer, err := aiComplete(req)
+		if err != nil {
+			t.Fatalf("aiComplete() error = %v", err)
+		}
+		return answer
+	}
+
+	first := complete(req)
+	if again := complete(req); again != first || requests.Load() != 1 {
+		t.Errorf("repeating the request got %q after %d requests, want the cached %q", again, requests.Load(), first)
+	}
+
+	req.Temperature = 0.7
+	if warmer := complete(req); warmer == first || requests.Load() != 2 {
+		t.Errorf("a different temperature got %q after %d requests, want a new answer", warmer, requests.Load())
+	}
+}
@@ -1,16 +1,13 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
 	"os"
 	"os/exec"
-	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/charmbracelet/huh"
-	"github.com/openai/openai-go/v3"
-	"github.com/openai/openai-go/v3/option"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
@@ -89,158 +86,86 @@ func getChangedFiles() ([]string, error) {
 	return strings.Split(strings.TrimSpace(string(stagedOutput)), "\n"), nil
 }
 
-// getProjectInfo gets information about the project
-func getProjectInfo() (string, error) {
-	// Try to determine the project type based on files
-	files, err := filepath.Glob("*")
+// generateCommitMessage renders one of the commit prompt templates and asks the model for a message.
+// Answers are cached per prompt; refresh asks for a new message instead of reusing the cached one.
+func generateCommitMessage(apiKey, baseURL, model, promptName string, data PromptData, refresh bool) (string, error) {
+	prompt, err := renderPrompt(promptName, data)
 	if err != nil {
-		return "", fmt.Errorf("failed to list files: %w", err)
+		return "", err
 	}
 
-	var projectInfo strings.Builder
-	projectInfo.WriteString("Project files include: ")
-
-	// Look for specific project indicators
-	hasGoMod := false
-	hasPackageJSON := false
-	hasPomXML := false
-	hasCMake := false
-	hasPyProject := false
-
-	for _, file := range files {
-		switch file {
-		case "go.mod":
-			hasGoMod = true
-		case "package.json":
-			hasPackageJSON = true
-		case "pom.xml":
-			hasPomXML = true
-		case "CMakeLists.txt":
-			hasCMake = true
-		case "pyproject.toml":
-			hasPyProject = true
-		}
-	}
-
-	if hasGoMod {
-		projectInfo.WriteString("Go project. ")
-	}
-	if hasPackageJSON {
-		projectInfo.WriteString("JavaScript/Node.js project. ")
-	}
-	if hasPomXML {
-		projectInfo.WriteString("Java/Maven project. ")
-	}
-	if hasCMake {
-		projectInfo.WriteString("C/C++ project with CMake. ")
-	}
-	if hasPyProject {
-		projectInfo.WriteString("Python project. ")
-	}
-
-	return projectInfo.String(), nil
-}
-
-// generateCommitMessage uses OpenAI to generate a commit message based on git diff and project information
-func generateCommitMessage(apiKey, baseURL, model, diff string) (string, error) {
-	if model == "" {
-		model = viper.GetString("default_model")
-	}
-
-	// Get changed files for more context
-	changedFiles, err := getChangedFiles()
-	if err != nil {
-		// Non-fatal error, we can continue without this info
-		fmt.Printf("%s Warning: couldn't get changed files: %v\n", styles.WarningIcon, err)
-	}
-
-	// Get project information for more context
-	projectInfo, err := getProjectInfo()
-	if err != nil {
-		// Non-fatal error, we can continue without this info
-		fmt.Printf("%s Warning: couldn't get project info: %v\n", styles.WarningIcon, err)
-	}
-
-	// Build file list string
-	var fileListStr string
-	if len(changedFiles) > 0 {
-		fileListStr = fmt.Sprintf("Changed files: %s\n\n", strings.Join(changedFiles, ", "))
-	}
-
-	// Prepare the prompt with more context
-	prompt := "Generate a short, concise git commit message based on the following changes. " +
-		"Follow the conventional commit format (e.g., feat:, fix:, docs:, style:, refactor:, test:, chore:). " +
-		"Keep it under 50 characters if possible. " +
-		"Only respond with the commit message, nothing else.\n\n"
-
-	if projectInfo != "" {
-		prompt += "Project information: " + projectInfo + "\n\n"
-	}
-
-	prompt += fileListStr + "Changes:\n" + diff
-
-	// Initialize OpenAI client with OpenRouter
-
-	client := openai.NewClient(
-		option.WithBaseURL(baseURL),
-		option.WithHeader("HTTP-Referer", "https://github.com/aixoio/tt"),
-		option.WithHeader("X-Title", "tt"),
-		option.WithAPIKey(apiKey),
-	)
-
-	resp, err := client.Chat.Completions.New(context.Background(), openai.ChatCompletionNewParams{
-		Model: model,
-		Messages: []openai.ChatCompletionMessageParamUnion{
-			openai.UserMessage(prompt),
-		},
-	})
+	message, err := aiComplete(AIRequest{APIKey: apiKey, BaseURL: baseURL, Model: model, Prompt: prompt, Cacheable: true, Refresh: refresh})
 	if err != nil {
 		return "", fmt.Errorf("failed to generate commit message: %w", err)
 	}
-
-	if len(resp.Choices) == 0 {
-		return "", fmt.Errorf("no response from AI model")
-	}
-
-	return strings.TrimSpace(resp.Choices[0].Message.Content), nil
+	return message, nil
 }
 
-// makeCommit creates a git commit with the provided message
-func makeCommit(message string) error {
-	// Stage all changes
-	addCmd := exec.Command("git", "add", ".")
-	addCmd.Stdout = os.Stdout
-	addCmd.Stderr = os.Stderr
-	if err := addCmd.Run(); err != nil {
-		return fmt.Errorf("failed to stage changes: %w", err)
+// makeCommit commits the staged changes with the provided message, adding the
+// ticket, Co-authored-by and the configured trailers
+func makeCommit(message string, coAuthors []string, sign bool) error {
+	message, err := decorateMessage(message, coAuthors)
+	if err != nil {
+		return err
 	}
 
 	// Create commit
-	commitCmd := exec.Command("git", "commit", "-m", message)
+	commitCmd := exec.Command("git", withSigning(sign, "-S", "commit", "-m", message)...)
 	commitCmd.Stdout = os.Stdout
 	commitCmd.Stderr = os.Stderr
 	return commitCmd.Run()
 }
 
 var (
-	autoCommit bool
-	model      string
-	addFlag    bool
-	pushFlag   bool
+	autoCommit  bool
+	model       string
+	addFlag     bool
+	pushFlag    bool
+	offlineFlag bool
+	// allowSecretsFlag lets aic proceed past secret scanner findings
+	allowSecretsFlag bool
+	candidatesFlag   int
+	pairFlag         []string
+	coAuthorsFlag    bool
 )
 
+// spinCandidates generates n candidate messages behind a spinner
+func spinCandidates(apiKey, model string, n int, data PromptData) ([]commitCandidate, error) {
+	var generated []commitCandidate
+	err := runWithSpinner(fmt.Sprintf("🤖 Generating %d commit messages...", n), func() error {
+		var err error
+		generated, err = generateCandidates(apiKey, viper.GetString("base_url"), candidateModels(model, n), data)
+		return err
+	})
+	if err == nil && len(generated) < n {
+		fmt.Println(styles.InfoIcon + " " + styles.Info.Render(fmt.Sprintf("Got %d distinct message(s) of the %d requested; failed and duplicate answers were dropped", len(generated), n)))
+	}
+	return generated, err
+}
+
+// chooseCandidate lets the user pick and edit one of the generated messages
+// and adds the others to the session history
+func chooseCandidate(title string, generated []commitCandidate, history []string) (string, []string, error) {
+	message, index, err := pickCandidate(title, generated)
+	if err != nil {
+		return "", history, err
+	}
+	for i, candidate := range generated {
+		if i != index {
+			history = rememberMessages(history, candidate.Message)
+		}
+	}
+	return message, history, nil
+}
+
 var aicCmd = &cobra.Command{
 	Use:     "aic",
 	Aliases: []string{"ai-commit", "ai", "ac", "a"},
 	Short:   "Generate AI-powered commit messages",
-	Long:    styles.Info.Render("Use AI to generate conventional commit messages based on your changes."),
+	Long:    styles.Info.Render("Use AI to generate conventional commit messages based on your changes. Without an API key, or when the request fails, a rule-based generator infers the message from the diff."),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		// Check if API key is set
 		apiKey := viper.GetString("api_key")
-		if apiKey == "" {
-			fmt.Println(styles.ErrorIcon + " " + styles.Error.Render("API key not set. Run 'tt set' to configure it."))
-			return fmt.Errorf("API key not set")
-		}
+		offline := offlineFlag
 
 		// Handle alias behavior - 'a' automatically enables auto-commit and add
 		if cmd.CalledAs() == "a" {
@@ -252,14 +177,31 @@ var aicCmd = &cobra.Command{
 		fmt.Println(styles.Header.Render("AI Commit"))
 		fmt.Println()
 
-		// Handle file staging
-		if addFlag {
-			fmt.Print(styles.InfoIcon + " " + styles.Info.Render("Staging all files... "))
-			if err := exec.Command("git", "add", ".").Run(); err != nil {
-				fmt.Println(styles.ErrorIcon)
-				return fmt.Errorf("failed to add files: %w", err)
-			}
-			fmt.Println(styles.SuccessIcon)
+		// Without an API key, fall back to the offline generator
+		if apiKey == "" && !offline {
+			fmt.Println(styles.WarningIcon + " " + styles.Warning.Render("API key not set, using the offline message generator. Run 'tt set' to configure it."))
+			offline = true
+		}
+
+		// The commit includes every change, so stage them before generating:
+		// the message then describes what is committed, and a blocked secret
+		// is caught before a request is spent or a message is picked
+		fmt.Print(styles.InfoIcon + " " + styles.Info.Render("Staging all files... "))
+		if err := exec.Command("git", "add", ".").Run(); err != nil {
+			fmt.Println(styles.ErrorIcon)
+			return fmt.Errorf("failed to add files: %w", err)
+		}
+		fmt.Println(styles.SuccessIcon)
+		if err := checkStagedSecrets(allowSecretsFlag); err != nil {
+			return err
+		}
+
+		coAuthors, err := resolveCoAuthors(pairFlag, coAuthorsFlag)
+		if err != nil {
+			return err
+		}
+		if len(coAuthors) > 0 {
+			fmt.Println(styles.InfoIcon + " " + styles.Info.Render("Co-authors: ") + styles.Highlight.Render(strings.Join(coAuthors, ", ")))
 		}
 
 		// Get git diff
@@ -271,22 +213,55 @@ var aicCmd = &cobra.Command{
 		}
 		fmt.Println(styles.SuccessIcon)
 
-		// Print which model is being used
-		modelToUse := model
-		if model == "" {
-			modelToUse = viper.GetString("default_model")
+		// Get changed files for more context
+		changedFiles, err := getChangedFiles()
+		if err != nil {
+			// Non-fatal error, we can continue without this info
+			fmt.Printf("%s Warning: couldn't get changed files: %v\n", styles.WarningIcon, err)
 		}
+		promptData := newPromptData(diff, changedFiles)
 
-		fmt.Println()
-		fmt.Println(styles.InfoIcon + " " + styles.Info.Render("Using model: ") + styles.Highlight.Render(modelToUse))
+		// Several candidates only make sense when someone is there to pick one
+		candidates := candidatesFlag
+		if candidates > 1 && autoCommit {
+			fmt.Println(styles.WarningIcon + " " + styles.Warning.Render("--candidates is ignored when committing automatically"))
+			candidates = 1
+		}
+		// history keeps the messages generated in this session but not chosen
+		var history []string
 
 		// Generate commit message
-		message, err := runWithSpinnerForMessage("🤖 Generating commit message...", func() (string, error) {
-			return generateCommitMessage(apiKey, viper.GetString("base_url"), model, diff)
-		})
-		if err != nil {
-			fmt.Println(styles.ErrorIcon + " " + styles.Error.Render("Failed to generate commit message"))
-			return err
+		var message string
+		if !offline {
+			// Print which model is being used
+			modelToUse := model
+			if model == "" {
+				modelToUse = viper.GetString("default_model")
+			}
+
+			fmt.Println()
+			if candidates > 1 {
+				fmt.Println(styles.InfoIcon + " " + styles.Info.Render("Using models: ") + styles.Highlight.Render(strings.Join(slices.Compact(slices.Sorted(slices.Values(candidateModels(model, candidates)))), ", ")))
+				var generated []commitCandidate
+				if generated, err = spinCandidates(apiKey, model, candidates, promptData); err == nil {
+					if message, history, err = chooseCandidate("Pick a commit message", generated, history); err != nil {
+						return err
+					}
+				}
+			} else {
+				fmt.Println(styles.InfoIcon + " " + styles.Info.Render("Using model: ") + styles.Highlight.Render(modelToUse))
+
+				message, err = runWithSpinnerForMessage("🤖 Generating commit message...", func() (string, error) {
+					return generateCommitMessage(apiKey, viper.GetString("base_url"), model, "commit", promptData, false)
+				})
+			}
+			if err != nil {
+				fmt.Println(styles.WarningIcon + " " + styles.Warning.Render("AI request failed, using the offline message generator: "+err.Error()))
+				offline = true
+			}
+		}
+		if offline {
+			message = offlineCommitMessage(diff)
 		}
 		fmt.Println(styles.SuccessIcon + " " + styles.Success.Render("Commit message generated successfully"))
 
@@ -297,10 +272,8 @@ var aicCmd = &cobra.Command{
 				styles.Highlight.Render(message),
 		))
 
-		// Handle commit based on auto-commit flag or user confirmation
-		if autoCommit {
-			// Auto-commit mode - commit without confirmation
-			if err := makeCommit(message); err != nil {
+		commitAndPush := func() error {
+			if err := makeCommit(message, coAuthors, shouldSign(cmd, "sign_commits")); err != nil {
 				return err
 			}
 			fmt.Println(styles.SuccessIcon + " " + styles.Success.Render("Commit created successfully"))
@@ -314,24 +287,45 @@ var aicCmd = &cobra.Command{
 					return fmt.Errorf("failed to push after commit: %w", err)
 				}
 			}
+			return nil
+		}
+
+		// Handle commit based on auto-commit flag or user confirmation
+		if autoCommit {
+			// Auto-commit mode - commit without confirmation
+			if err := commitAndPush(); err != nil {
+				return err
+			}
 		} else {
 			// Interactive options using huh
 			for {
 				var selectedOption string
 				var feedback string
+				previous := message
+
+				options := []huh.Option[string]{
+					huh.NewOption("✅ Create commit with this message", "commit"),
+					huh.NewOption("✏️  Edit message", "edit"),
+					huh.NewOption("❌ Cancel commit", "cancel"),
+				}
+				// Refining the message needs the model
+				if !offline {
+					options = append(options,
+						huh.NewOption("🔍 Generate more detailed message", "detailed"),
+						huh.NewOption("🔄 Retry with new generation", "retry"),
+						huh.NewOption("📝 Summarize message", "summarize"),
+						huh.NewOption("💬 Provide feedback for refinement", "feedback"),
+					)
+				}
+				if len(history) > 0 {
+					options = append(options, huh.NewOption(fmt.Sprintf("🗂  Choose from earlier messages (%d)", len(history)), "history"))
+				}
 
 				selectForm := huh.NewForm(
 					huh.NewGroup(
 						huh.NewSelect[string]().
 							Title(styles.Primary.Render("What would you like to do?")).
-							Options(
-								huh.NewOption("✅ Create commit with this message", "commit"),
-								huh.NewOption("❌ Cancel commit", "cancel"),
-								huh.NewOption("🔍 Generate more detailed message", "detailed"),
-								huh.NewOption("🔄 Retry with new generation", "retry"),
-								huh.NewOption("📝 Summarize message", "summarize"),
-								huh.NewOption("💬 Provide feedback for refinement", "feedback"),
-							).
+							Options(options...).
 							Value(&selectedOption),
 					),
 				).WithTheme(huh.ThemeCharm())
@@ -342,21 +336,23 @@ var aicCmd = &cobra.Command{
 
 				switch selectedOption {
 				case "commit":
-					if err := makeCommit(message); err != nil {
+					return commitAndPush()
+
+				case "edit":
+					edited, keep, err := editAndValidate(message)
+					if err != nil {
 						return err
 					}
-					fmt.Println(styles.SuccessIcon + " " + styles.Success.Render("Commit created successfully"))
-
-					// Handle auto-push
-					if pushFlag {
-						fmt.Println()
-						fmt.Println(styles.InfoIcon + " " + styles.Info.Render("Pushing changes... "))
-						if err := pushChanges(); err != nil {
-							fmt.Println(styles.Warning.Render("Push failed, but commit was successful"))
-							return fmt.Errorf("failed to push after commit: %w", err)
-						}
+					if !keep {
+						fmt.Println(styles.InfoIcon + " " + styles.Info.Render("Edit discarded"))
+						break
 					}
-					return nil
+					message = edited
+					fmt.Println(styles.Card.Render(
+						styles.Success.Render("Edited Commit Message:") + "\n" +
+							styles.Highlight.Render(message),
+					))
+					return commitAndPush()
 
 				case "cancel":
 					fmt.Println(styles.InfoIcon + " " + styles.Info.Render("Commit canceled"))
@@ -364,7 +360,7 @@ var aicCmd = &cobra.Command{
 
 				case "detailed":
 					message, err = runWithSpinnerForMessage("🔍 Generating a more detailed commit message...", func() (string, error) {
-						return generateCommitMessage(apiKey, viper.GetString("base_url"), model, diff+"\n\nPlease provide a more detailed commit message with additional context and explanations.")
+						return generateCommitMessage(apiKey, viper.GetString("base_url"), model, "commit-detailed", promptData, false)
 					})
 					if err != nil {
 						fmt.Println(styles.ErrorIcon)
@@ -378,8 +374,24 @@ var aicCmd = &cobra.Command{
 					))
 
 				case "retry":
+					retryData := promptData
+					retryData.Rejected = rememberMessages(slices.Clone(history), message)
+					if candidates > 1 {
+						generated, err := spinCandidates(apiKey, model, candidates, retryData)
+						if err != nil {
+							return err
+						}
+						if message, history, err = chooseCandidate("Pick a new commit message", generated, history); err != nil {
+							return err
+						}
+						fmt.Println(styles.Card.Render(
+							styles.Success.Render("Selected Commit Message:") + "\n" +
+								styles.Highlight.Render(message),
+						))
+						break
+					}
 					message, err = runWithSpinnerForMessage("🔄 Retrying with a new generation...", func() (string, error) {
-						return generateCommitMessage(apiKey, viper.GetString("base_url"), model, diff)
+						return generateCommitMessage(apiKey, viper.GetString("base_url"), model, "commit", retryData, true)
 					})
 					if err != nil {
 						fmt.Println(styles.ErrorIcon)
@@ -394,7 +406,9 @@ var aicCmd = &cobra.Command{
 
 				case "summarize":
 					message, err = runWithSpinnerForMessage("📝 Summarizing the commit message...", func() (string, error) {
-						return generateCommitMessage(apiKey, viper.GetString("base_url"), model, "Please summarize this commit message in 50 characters or less:\n\n"+message)
+						summarizeData := promptData
+						summarizeData.Message = message
+						return generateCommitMessage(apiKey, viper.GetString("base_url"), model, "commit-summarize", summarizeData, false)
 					})
 					if err != nil {
 						fmt.Println(styles.ErrorIcon)
@@ -428,9 +442,11 @@ var aicCmd = &cobra.Command{
 						return fmt.Errorf("error getting feedback: %w", err)
 					}
 
-					promptWithGuidance := "Based on this diff:\n\n" + diff + "\n\nAnd considering this feedback: " + feedback + "\n\nGenerate an appropriate commit message."
+					feedbackData := promptData
+					feedbackData.Message = message
+					feedbackData.Feedback = feedback
 					message, err = runWithSpinnerForMessage("🎯 Generating commit message based on your feedback...", func() (string, error) {
-						return generateCommitMessage(apiKey, viper.GetString("base_url"), model, promptWithGuidance)
+						return generateCommitMessage(apiKey, viper.GetString("base_url"), model, "commit-feedback", feedbackData, false)
 					})
 					if err != nil {
 						fmt.Println(styles.ErrorIcon)
@@ -442,6 +458,27 @@ var aicCmd = &cobra.Command{
 						styles.Success.Render("Feedback-Based Commit Message:") + "\n" +
 							styles.Highlight.Render(message),
 					))
+
+				case "history":
+					earlier := make([]commitCandidate, len(history))
+					for i, past := range history {
+						earlier[i] = commitCandidate{Message: past}
+					}
+					chosen, index, err := pickCandidate("Choose an earlier message", earlier)
+					if err != nil {
+						return err
+					}
+					history = slices.Delete(history, index, index+1)
+					message = chosen
+					fmt.Println(styles.Card.Render(
+						styles.Success.Render("Selected Commit Message:") + "\n" +
+							styles.Highlight.Render(message),
+					))
+				}
+
+				// Keep the replaced message so it can be picked again later
+				if message != previous {
+					history = rememberMessages(history, previous)
 				}
 			}
 		}
@@ -454,6 +491,12 @@ func init() {
 	rootCmd.AddCommand(aicCmd)
 	aicCmd.Flags().BoolVarP(&autoCommit, "commit", "c", false, "Automatically create commit with generated message")
 	aicCmd.Flags().StringVarP(&model, "model", "m", "", "OpenRouter model to use for generation (overrides default_model from config)")
-	aicCmd.Flags().BoolVarP(&addFlag, "add", "a", false, "Add all files before committing")
+	aicCmd.Flags().BoolVarP(&addFlag, "add", "a", false, "Add all files before committing (aic always stages every change)")
 	aicCmd.Flags().BoolVarP(&pushFlag, "push", "p", false, "Push after committing")
+	aicCmd.Flags().BoolVar(&offlineFlag, "offline", false, "Generate the message from the diff with local rules instead of a model")
+	aicCmd.Flags().IntVar(&candidatesFlag, "candidates", 1, "Generate this many messages, across candidate_models if set, and pick one")
+	aicCmd.Flags().StringSliceVar(&pairFlag, "pair", nil, "Add a co-author by team roster alias or \"Name <email>\" (repeatable)")
+	aicCmd.Flags().BoolVar(&coAuthorsFlag, "co-authors", false, "Pick co-authors from the team roster and recent authors")
+	aicCmd.Flags().BoolVar(&allowSecretsFlag, "allow-secrets", false, "Commit even if the secret scanner finds something")
+	aicCmd.Flags().BoolP("sign", "S", false, "Sign the commit with GPG or SSH (overrides sign_commits from config)")
 }
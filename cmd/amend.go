@@ -0,0 +1,219 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/charmbracelet/huh"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/aixoio/tt/styles"
+)
+
+// emptyTree is the hash of git's empty tree, used to diff against a root commit
+const emptyTree = "4b825dc642cb6eb9a060e54bf8d69288fbee4904"
+
+// isPushed reports whether a commit is on any remote-tracking branch
+func isPushed(rev string) bool {
+	output, err := exec.Command("git", "branch", "-r", "--contains", rev).Output()
+	return err == nil && len(strings.TrimSpace(string(output))) > 0
+}
+
+// parentOf returns the first parent of a commit, or the empty tree for a root commit
+func parentOf(rev string) string {
+	if output, err := exec.Command("git", "rev-parse", "--verify", "--quiet", rev+"^").Output(); err == nil {
+		return strings.TrimSpace(string(output))
+	}
+	return emptyTree
+}
+
+// amendDiff is what HEAD will contain after amending: its own changes plus the staged ones
+func amendDiff() (string, []string, error) {
+	base := parentOf("HEAD")
+	diff, err := exec.Command("git", "diff", "--cached", "--no-color", base).Output()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get changes: %w", err)
+	}
+	names, err := exec.Command("git", "diff", "--cached", "--name-only", base).Output()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get changed files: %w", err)
+	}
+	return string(diff), strings.Fields(string(names)), nil
+}
+
+var amendCmd = &cobra.Command{
+	Use:   "amend",
+	Short: "Add staged changes to the last commit, optionally rewriting its message",
+	Long:  styles.Info.Render("Amend HEAD with the staged changes. Keep the message, replace it with -m, edit it with --edit, or generate a new one from the amended changes with --ai. Warns before rewriting a commit that is already pushed."),
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		message, _ := cmd.Flags().GetString("message")
+		edit, _ := cmd.Flags().GetBool("edit")
+		useAI, _ := cmd.Flags().GetBool("ai")
+		add, _ := cmd.Flags().GetBool("add")
+		allowSecrets, _ := cmd.Flags().GetBool("allow-secrets")
+
+		fmt.Println(styles.Header.Render("Amend Commit"))
+		fmt.Println()
+
+		headMessage, err := exec.Command("git", "log", "-1", "--format=%B").Output()
+		if err != nil {
+			fmt.Println(styles.ErrorIcon + " " + styles.Error.Render("No commit to amend"))
+			return fmt.Errorf("no commit to amend")
+		}
+		current := strings.TrimSpace(string(headMessage))
+
+		if add {
+			fmt.Print(styles.InfoIcon + " " + styles.Info.Render("Staging all files... "))
+			if err := exec.Command("git", "add", ".").Run(); err != nil {
+				fmt.Println(styles.ErrorIcon)
+				return fmt.Errorf("failed to add files: %w", err)
+			}
+			fmt.Println(styles.SuccessIcon)
+		}
+
+		staged, err := exec.Command("git", "diff", "--cached", "--stat", "--no-color").Output()
+		if err != nil {
+			return fmt.Errorf("failed to get staged changes: %w", err)
+		}
+		if len(staged) == 0 && message == "" && !edit && !useAI {
+			fmt.Println(styles.InfoIcon + " " + styles.Info.Render("Nothing staged. Stage changes, or pass -m, --edit or --ai to reword the commit."))
+			return nil
+		}
+
+		if isPushed("HEAD") {
+			var proceed bool
+			confirm := huh.NewConfirm().
+				Title(styles.WarningIcon + " " + styles.Warning.Render("HEAD is already pushed")).
+				Description("Amending rewrites it, so you will need to force-push and anyone who pulled it will have to recover. Amend anyway?").
+				Affirmative("Amend").
+				Negative("Cancel").
+				Value(&proceed).
+				WithTheme(huh.ThemeCharm())
+			if err := confirm.Run(); err != nil {
+				return fmt.Errorf("failed to show confirmation prompt: %w", err)
+			}
+			if !proceed {
+				fmt.Println(styles.InfoIcon + " " + styles.Info.Render("Amend canceled"))
+				return nil
+			}
+		}
+
+		if err := checkStagedSecrets(allowSecrets); err != nil {
+			return err
+		}
+
+		switch {
+		case message != "":
+		case useAI:
+			apiKey := viper.GetString("api_key")
+			if apiKey == "" {
+				fmt.Println(styles.ErrorIcon + " " + styles.Error.Render("API key not set. Run 'tt set' to configure it."))
+				return fmt.Errorf("API key not set")
+			}
+			diff, files, err := amendDiff()
+			if err != nil {
+				return err
+			}
+			aiModel, _ := cmd.Flags().GetString("model")
+			generated, err := runWithSpinnerForMessage("🤖 Generating commit message...", func() (string, error) {
+				return generateCommitMessage(apiKey, viper.GetString("base_url"), aiModel, "commit", newPromptData(diff, files), false)
+			})
+			if err != nil {
+				fmt.Println(styles.ErrorIcon)
+				return err
+			}
+			fmt.Println(styles.Card.Render(
+				styles.Neutral.Render("Current: ") + styles.Muted.Render(strings.SplitN(current, "\n", 2)[0]) + "\n" +
+					styles.Success.Render("Generated Commit Message:") + "\n" +
+					styles.Highlight.Render(generated),
+			))
+
+			var choice string
+			selectForm := huh.NewForm(
+				huh.NewGroup(
+					huh.NewSelect[string]().
+						Title(styles.Primary.Render("What would you like to do?")).
+						Options(
+							huh.NewOption("✅ Amend with this message", "use"),
+							huh.NewOption("✏️  Edit message", "edit"),
+							huh.NewOption("↩️  Keep the current message", "keep"),
+							huh.NewOption("❌ Cancel", "cancel"),
+						).
+						Value(&choice),
+				),
+			).WithTheme(huh.ThemeCharm())
+			if err := selectForm.Run(); err != nil {
+				return fmt.Errorf("error getting user selection: %w", err)
+			}
+
+			switch choice {
+			case "use":
+				message = generated
+			case "edit":
+				edited, keep, err := editAndValidate(generated)
+				if err != nil {
+					return err
+				}
+				if keep {
+					message = edited
+				}
+			case "cancel":
+				fmt.Println(styles.InfoIcon + " " + styles.Info.Render("Amend canceled"))
+				return nil
+			}
+		case edit:
+			edited, keep, err := editAndValidate(current)
+			if err != nil {
+				return err
+			}
+			if !keep {
+				fmt.Println(styles.InfoIcon + " " + styles.Info.Render("Amend canceled"))
+				return nil
+			}
+			message = edited
+		}
+
+		gitArgs := []string{"commit", "--amend", "--no-edit"}
+		if message != "" {
+			// A new message gets the same ticket and trailers as a new commit,
+			// and keeps the co-authors of the one it replaces
+			decorated, err := decorateMessage(message, messageCoAuthors(current))
+			if err != nil {
+				return err
+			}
+			gitArgs = []string{"commit", "--amend", "-m", decorated}
+		}
+		gitCmd := exec.Command("git", withSigning(shouldSign(cmd, "sign_commits"), "-S", gitArgs...)...)
+		gitCmd.Stdout = os.Stdout
+		gitCmd.Stderr = os.Stderr
+		if err := gitCmd.Run(); err != nil {
+			return fmt.Errorf("failed to amend commit: %w", err)
+		}
+
+		hash, _ := exec.Command("git", "rev-parse", "--short", "HEAD").Output()
+		subject, _ := exec.Command("git", "log", "-1", "--format=%s").Output()
+		fmt.Println(styles.Card.Render(
+			styles.Success.Render("Commit amended!") + "\n" +
+				styles.CommitHash.Render(strings.TrimSpace(string(hash))) + " " + styles.Highlight.Render(strings.TrimSpace(string(subject))),
+		))
+		if isPushed("HEAD@{1}") {
+			fmt.Println(styles.InfoIcon + " " + styles.Info.Render("The old commit is on the remote; update it with ") + styles.Highlight.Render("git push --force-with-lease"))
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(amendCmd)
+	amendCmd.Flags().StringP("message", "m", "", "Replace the commit message")
+	amendCmd.Flags().BoolP("edit", "e", false, "Edit the current commit message")
+	amendCmd.Flags().Bool("ai", false, "Generate a new message from the amended changes")
+	amendCmd.Flags().String("model", "", "OpenRouter model to use with --ai (overrides default_model from config)")
+	amendCmd.Flags().BoolP("add", "a", false, "Add all files before amending")
+	amendCmd.Flags().Bool("allow-secrets", false, "Amend even if the secret scanner finds something")
+	amendCmd.Flags().BoolP("sign", "S", false, "Sign the commit with GPG or SSH (overrides sign_commits from config)")
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -24,6 +25,12 @@ var apCmd = &cobra.Command{
 		// Set flags for auto-commit and add
 		aicCommand.Flags().Set("commit", "true")
 		aicCommand.Flags().Set("add", "true")
+		if allowSecrets, _ := cmd.Flags().GetBool("allow-secrets"); allowSecrets {
+			aicCommand.Flags().Set("allow-secrets", "true")
+		}
+		if pair, _ := cmd.Flags().GetStringSlice("pair"); len(pair) > 0 {
+			aicCommand.Flags().Set("pair", strings.Join(pair, ","))
+		}
 
 		// Execute the aic command
 		if err := aicCommand.RunE(cmd, args); err != nil {
@@ -50,4 +57,7 @@ var apCmd = &cobra.Command{
 
 func init() {
 	rootCmd.AddCommand(apCmd)
+	apCmd.Flags().StringSlice("pair", nil, "Add a co-author by team roster alias or \"Name <email>\" (repeatable)")
+	apCmd.Flags().Bool("allow-secrets", false, "Commit even if the secret scanner finds something")
+	apCmd.Flags().BoolP("sign", "S", false, "Sign the commit with GPG or SSH (overrides sign_commits from config)")
 }
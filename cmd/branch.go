@@ -17,8 +17,12 @@ var branchCmd = &cobra.Command{
 	Use:     "branch [command] [args]",
 	Aliases: []string{"b"},
 	Short:   "Create, switch, and list git branches",
-	Long:    styles.Info.Render("Manage git branches: create new branches, switch to existing ones, or list all branches with a commit graph."),
+	Long:    styles.Info.Render("Manage git branches: create new branches, switch to existing ones, or list all branches with a commit graph. New branch names are checked against branch_policy; --new builds one that passes."),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if newFlag, _ := cmd.Flags().GetBool("new"); newFlag {
+			return newBranchForm(cmd)
+		}
+
 		if len(args) == 0 {
 			return listBranches()
 		}
@@ -60,7 +64,8 @@ func handleSwitchOrCreate(name string, cmd *cobra.Command) error {
 	verifyCmd := exec.Command("git", "rev-parse", "--verify", name)
 	if err := verifyCmd.Run(); err != nil {
 		// Branch does not exist, create it
-		return createBranch(name, cmd)
+		pushFlag, _ := cmd.Flags().GetBool("push")
+		return createBranch(name, "", pushFlag)
 	}
 
 	// Branch exists, switch to it
@@ -77,6 +82,9 @@ func listBranches() error {
 	if currentOutput, err := currentBranchCmd.Output(); err == nil {
 		currentBranch := strings.TrimSpace(string(currentOutput))
 		fmt.Println(styles.InfoIcon + " " + styles.Info.Render("Current branch: ") + styles.Branch.Render(currentBranch))
+		if ticket := branchTicket(currentBranch); ticket.Key != "" {
+			fmt.Println(styles.InfoIcon + " " + styles.Info.Render("Ticket: ") + renderTicket(ticket))
+		}
 		fmt.Println()
 	}
 
@@ -88,10 +96,14 @@ func listBranches() error {
 		for branch := range strings.SplitSeq(branches, "\n") {
 			branch = strings.TrimSpace(branch)
 			if branch != "" {
+				var ticketKey string
+				if ticket := branchTicket(strings.TrimSpace(strings.TrimPrefix(branch, "*"))); ticket.Key != "" {
+					ticketKey = " " + styles.Muted.Render(ticket.Key)
+				}
 				if strings.HasPrefix(branch, "*") {
-					fmt.Println("  " + styles.SuccessIcon + " " + styles.Branch.Render(branch[1:]) + " (current)")
+					fmt.Println("  " + styles.SuccessIcon + " " + styles.Branch.Render(branch[1:]) + " (current)" + ticketKey)
 				} else {
-					fmt.Println("  • " + styles.Neutral.Render(branch))
+					fmt.Println("  • " + styles.Neutral.Render(branch) + ticketKey)
 				}
 			}
 		}
@@ -233,12 +245,20 @@ func deleteBranch(name string, cmd *cobra.Command) error {
 	return nil
 }
 
-// createBranch creates a new branch with the given name
-func createBranch(branchName string, cmd *cobra.Command) error {
+// createBranch creates a new branch with the given name at startPoint (HEAD when empty)
+func createBranch(branchName, startPoint string, push bool) error {
 	if branchName == "" {
 		fmt.Println(styles.ErrorIcon + " " + styles.Error.Render("Branch name cannot be empty"))
 		return fmt.Errorf("branch name cannot be empty")
 	}
+	if problems := branchNameProblems(branchName, branchPolicy()); len(problems) > 0 {
+		fmt.Println(styles.ErrorIcon + " " + styles.Error.Render("Branch name "+branchName+" does not follow the naming policy:"))
+		for _, problem := range problems {
+			fmt.Println("  • " + styles.Neutral.Render(problem))
+		}
+		fmt.Println(styles.InfoIcon + " " + styles.Info.Render("Run 'tt branch --new' to build a name that does"))
+		return fmt.Errorf("branch name does not follow the naming policy")
+	}
 
 	// Show header
 	fmt.Println(styles.Header.Render("Git Branch"))
@@ -257,7 +277,11 @@ func createBranch(branchName string, cmd *cobra.Command) error {
 
 	// Create the new branch
 	fmt.Print(styles.Spinner.Render("⏳") + " " + styles.Info.Render("Creating branch... "))
-	gitCreateCmd := exec.Command("git", "checkout", "-b", branchName)
+	createArgs := []string{"checkout", "-b", branchName}
+	if startPoint != "" {
+		createArgs = append(createArgs, startPoint)
+	}
+	gitCreateCmd := exec.Command("git", createArgs...)
 	gitCreateCmd.Stdout = os.Stdout
 	gitCreateCmd.Stderr = os.Stderr
 	if err := gitCreateCmd.Run(); err != nil {
@@ -274,8 +298,7 @@ func createBranch(branchName string, cmd *cobra.Command) error {
 			styles.Neutral.Render("Status: ") + styles.Success.Render("Switched to new branch"),
 	))
 
-	pushFlag, _ := cmd.Flags().GetBool("push")
-	if pushFlag {
+	if push {
 		fmt.Println()
 		fmt.Println(styles.InfoIcon + " " + styles.Info.Render("Pushing branch to remote... "))
 		if err := pushChangesToNewBranch(branchName); err != nil {
@@ -303,4 +326,38 @@ func init() {
 	rootCmd.AddCommand(branchCmd)
 	branchCmd.Flags().BoolP("push", "p", false, "Auto-push the new branch and set upstream")
 	branchCmd.Flags().Bool("remote", false, "Delete remote branch instead of local")
+	branchCmd.Flags().BoolP("new", "n", false, "Name a new branch with a guided form, optionally suggested from your changes")
+}
+
+// getDefaultBranch returns the branch pull requests are usually opened against,
+// preferring the remote's HEAD, then main or master, then init.defaultBranch
+func getDefaultBranch() (string, error) {
+	if output, err := exec.Command("git", "symbolic-ref", "--quiet", "--short", "refs/remotes/origin/HEAD").Output(); err == nil {
+		if ref := strings.TrimSpace(string(output)); ref != "" {
+			return ref, nil
+		}
+	}
+
+	for _, candidate := range []string{"origin/main", "origin/master", "main", "master"} {
+		if exec.Command("git", "rev-parse", "--verify", "--quiet", candidate).Run() == nil {
+			return candidate, nil
+		}
+	}
+
+	if output, err := exec.Command("git", "config", "init.defaultBranch").Output(); err == nil {
+		if name := strings.TrimSpace(string(output)); name != "" && exec.Command("git", "rev-parse", "--verify", "--quiet", name).Run() == nil {
+			return name, nil
+		}
+	}
+
+	return "", fmt.Errorf("could not determine the default branch, pass one explicitly")
+}
+
+// getMergeBase returns the best common ancestor of two revisions
+func getMergeBase(a, b string) (string, error) {
+	output, err := exec.Command("git", "merge-base", a, b).Output()
+	if err != nil {
+		return "", fmt.Errorf("no merge-base between %s and %s", a, b)
+	}
+	return strings.TrimSpace(string(output)), nil
 }
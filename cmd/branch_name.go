@@ -0,0 +1,233 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/charmbracelet/huh"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/aixoio/tt/styles"
+)
+
+// BranchPolicy is the naming policy new branches are checked against. An
+// empty policy accepts any name git accepts.
+type BranchPolicy struct {
+	Prefixes      []string `mapstructure:"prefixes"`
+	RequireTicket bool     `mapstructure:"require_ticket"`
+	KebabCase     bool     `mapstructure:"kebab_case"`
+	MaxLength     int      `mapstructure:"max_length"`
+	Template      string   `mapstructure:"template"`
+}
+
+// BranchNameData holds the parts the branch_policy.template combines
+type BranchNameData struct {
+	Type        string
+	Ticket      string
+	Description string
+}
+
+// defaultBranchTypes are offered by tt branch --new when no prefixes are configured
+var defaultBranchTypes = []string{"feat", "fix", "chore", "docs", "refactor", "test"}
+
+// defaultBranchTemplate names branches like feat/ABC-123-add-login
+const defaultBranchTemplate = "{{.Type}}/{{if .Ticket}}{{.Ticket}}-{{end}}{{.Description}}"
+
+var (
+	kebabRe    = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+	nonSlugRe  = regexp.MustCompile(`[^a-z0-9]+`)
+	slashRunRe = regexp.MustCompile(`/+`)
+)
+
+func branchPolicy() BranchPolicy {
+	var policy BranchPolicy
+	// A malformed policy is treated as empty rather than blocking branch creation
+	_ = viper.UnmarshalKey("branch_policy", &policy)
+	if policy.Template == "" {
+		policy.Template = defaultBranchTemplate
+	}
+	return policy
+}
+
+// branchNameProblems lists the ways a name breaks the policy
+func branchNameProblems(name string, policy BranchPolicy) []string {
+	var problems []string
+	// The part after the type prefix is what kebab-case applies to
+	prefix, rest, found := strings.Cut(name, "/")
+	if !found {
+		prefix, rest = "", name
+	}
+
+	if len(policy.Prefixes) > 0 && !slices.Contains(policy.Prefixes, prefix) {
+		problems = append(problems, "start with one of "+strings.Join(policy.Prefixes, "/, ")+"/")
+	}
+
+	var ticket Ticket
+	if policy.RequireTicket || policy.KebabCase {
+		var err error
+		if ticket, err = configuredTicket(name); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+	if policy.RequireTicket && ticket.Key == "" {
+		problems = append(problems, "include a ticket key, such as ABC-123")
+	}
+
+	if policy.KebabCase {
+		// Ticket keys keep their own case, so only the words around them are checked
+		words := rest
+		if ticket.Key != "" {
+			words = strings.Replace(words, ticket.Key, "", 1)
+		}
+		words = strings.Trim(words, "-")
+		if words != "" && !kebabRe.MatchString(words) {
+			problems = append(problems, "use lowercase words joined by hyphens (kebab-case)")
+		}
+	}
+
+	if policy.MaxLength > 0 && len(name) > policy.MaxLength {
+		problems = append(problems, fmt.Sprintf("keep it to %d characters (it has %d)", policy.MaxLength, len(name)))
+	}
+	return problems
+}
+
+// slugify turns free text into lowercase words joined by hyphens
+func slugify(text string) string {
+	return strings.Trim(nonSlugRe.ReplaceAllString(strings.ToLower(text), "-"), "-")
+}
+
+// buildBranchName fills the policy template and trims the description so the
+// name fits within the maximum length, cutting at a word boundary
+func buildBranchName(data BranchNameData, policy BranchPolicy) (string, error) {
+	tmpl, err := template.New("branch").Parse(policy.Template)
+	if err != nil {
+		return "", fmt.Errorf("invalid branch_policy.template: %w", err)
+	}
+
+	data.Description = slugify(data.Description)
+	for {
+		var sb strings.Builder
+		if err := tmpl.Execute(&sb, data); err != nil {
+			return "", fmt.Errorf("invalid branch_policy.template: %w", err)
+		}
+		name := strings.Trim(slashRunRe.ReplaceAllString(sb.String(), "/"), "/-")
+		if policy.MaxLength <= 0 || len(name) <= policy.MaxLength || !strings.Contains(data.Description, "-") {
+			return name, nil
+		}
+		data.Description = data.Description[:strings.LastIndex(data.Description, "-")]
+	}
+}
+
+// suggestBranchDescription asks the model to describe the uncommitted changes in a few words
+func suggestBranchDescription(apiKey string) (string, error) {
+	diff, err := getGitDiff()
+	if err != nil {
+		return "", err
+	}
+	files, _ := getChangedFiles()
+	prompt, err := renderPrompt("branch", newPromptData(diff, files))
+	if err != nil {
+		return "", err
+	}
+	answer, err := aiComplete(AIRequest{APIKey: apiKey, Prompt: prompt, Cacheable: true})
+	if err != nil {
+		return "", err
+	}
+	return slugify(answer), nil
+}
+
+// newBranchForm guides through naming a branch: its type, ticket and description,
+// which the model can suggest from the uncommitted changes
+func newBranchForm(cmd *cobra.Command) error {
+	policy := branchPolicy()
+
+	types := policy.Prefixes
+	if len(types) == 0 {
+		types = defaultBranchTypes
+	}
+	typeOptions := make([]huh.Option[string], len(types))
+	for i, branchType := range types {
+		typeOptions[i] = huh.NewOption(branchType, branchType)
+	}
+
+	var data BranchNameData
+	// Carry over the ticket of the branch we are on, since follow-up work often shares it
+	data.Ticket = currentTicket().Key
+
+	source := "write"
+	fields := []huh.Field{
+		huh.NewSelect[string]().
+			Title(styles.Primary.Render("Type")).
+			Options(typeOptions...).
+			Value(&data.Type),
+		huh.NewInput().
+			Title(styles.Primary.Render("Ticket")).
+			Placeholder("ABC-123").
+			Value(&data.Ticket).
+			Validate(func(s string) error {
+				if s == "" && policy.RequireTicket {
+					return fmt.Errorf("a ticket is required")
+				}
+				return nil
+			}),
+	}
+	apiKey := viper.GetString("api_key")
+	if apiKey != "" {
+		fields = append(fields, huh.NewSelect[string]().
+			Title(styles.Primary.Render("Description")).
+			Options(
+				huh.NewOption("Write it myself", "write"),
+				huh.NewOption("🤖 Suggest one from my uncommitted changes", "suggest"),
+			).
+			Value(&source))
+	}
+	if err := huh.NewForm(huh.NewGroup(fields...)).WithTheme(huh.ThemeCharm()).Run(); err != nil {
+		return fmt.Errorf("failed to get branch details: %w", err)
+	}
+	data.Ticket = strings.TrimSpace(data.Ticket)
+
+	if source == "suggest" {
+		suggestion, err := runWithSpinnerForMessage("🤖 Suggesting a branch name...", func() (string, error) {
+			return suggestBranchDescription(apiKey)
+		})
+		if err != nil {
+			fmt.Println(styles.WarningIcon + " " + styles.Warning.Render("Could not suggest a name: "+err.Error()))
+		}
+		data.Description = suggestion
+	}
+
+	var name string
+	descriptionForm := huh.NewForm(
+		huh.NewGroup(
+			huh.NewInput().
+				Title(styles.Primary.Render("Description")).
+				Placeholder("add login form").
+				Description("A few words; they are joined with hyphens").
+				Value(&data.Description).
+				Validate(func(s string) error {
+					if slugify(s) == "" {
+						return fmt.Errorf("description cannot be empty")
+					}
+					built, err := buildBranchName(BranchNameData{Type: data.Type, Ticket: data.Ticket, Description: s}, policy)
+					if err != nil {
+						return err
+					}
+					if problems := branchNameProblems(built, policy); len(problems) > 0 {
+						return fmt.Errorf("%s: %s", built, strings.Join(problems, "; "))
+					}
+					name = built
+					return nil
+				}),
+		),
+	).WithTheme(huh.ThemeCharm())
+	if err := descriptionForm.Run(); err != nil {
+		return fmt.Errorf("failed to get branch description: %w", err)
+	}
+
+	pushFlag, _ := cmd.Flags().GetBool("push")
+	return createBranch(name, "", pushFlag)
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBranchNameProblems(t *testing.T) {
+	policy := BranchPolicy{Prefixes: []string{"feat", "fix", "chore"}, RequireTicket: true, KebabCase: true, MaxLength: 40}
+
+	tests := []struct {
+		name string
+		want []string
+	}{
+		{"feat/ABC-123-add-login", nil},
+		{"feature/ABC-123-add-login", []string{"start with one of"}},
+		{"fix/add-login", []string{"ticket key"}},
+		{"fix/ABC-123-Add_Login", []string{"kebab-case"}},
+		{"chore/ABC-123-" + strings.Repeat("word-", 8) + "end", []string{"40 characters"}},
+	}
+	for _, tt := range tests {
+		problems := branchNameProblems(tt.name, policy)
+		if len(problems) != len(tt.want) {
+			t.Errorf("branchNameProblems(%q) = %v, want %d problem(s)", tt.name, problems, len(tt.want))
+			continue
+		}
+		for i, want := range tt.want {
+			if !strings.Contains(problems[i], want) {
+				t.Errorf("branchNameProblems(%q)[%d] = %q, want it to mention %q", tt.name, i, problems[i], want)
+			}
+		}
+	}
+
+	if problems := branchNameProblems("Anything_Goes", BranchPolicy{}); len(problems) != 0 {
+		t.Errorf("an empty policy should accept any name, got %v", problems)
+	}
+}
+
+func TestBuildBranchName(t *testing.T) {
+	policy := BranchPolicy{Template: defaultBranchTemplate, MaxLength: 30}
+
+	tests := []struct {
+		data BranchNameData
+		want string
+	}{
+		{BranchNameData{Type: "feat", Ticket: "ABC-123", Description: "Add login form"}, "feat/ABC-123-add-login-form"},
+		{BranchNameData{Type: "fix", Description: "  crash on start!  "}, "fix/crash-on-start"},
+		{BranchNameData{Type: "feat", Ticket: "ABC-123", Description: "retry failed uploads with backoff"}, "feat/ABC-123-retry-failed"},
+	}
+	for _, tt := range tests {
+		got, err := buildBranchName(tt.data, policy)
+		if err != nil {
+			t.Fatalf("buildBranchName(%+v) error = %v", tt.data, err)
+		}
+		if got != tt.want {
+			t.Errorf("buildBranchName(%+v) = %q, want %q", tt.data, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// cacheKey hashes the inputs that fully determine a cached result
+func cacheKey(parts ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
+
+// cachePath returns where an entry lives under ~/.tt/cache/<namespace>
+func cachePath(namespace, key string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".tt", "cache", namespace, key), nil
+}
+
+// readCache returns a cached value, reporting false when there is none
+func readCache(namespace, key string) (string, bool) {
+	path, err := cachePath(namespace, key)
+	if err != nil {
+		return "", false
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", false
+	}
+	return string(content), true
+}
+
+// writeCache stores a value, creating the namespace directory as needed
+func writeCache(namespace, key, value string) error {
+	path, err := cachePath(namespace, key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(value), 0644)
+}
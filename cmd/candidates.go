@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/huh"
+	"github.com/spf13/viper"
+
+	"github.com/aixoio/tt/styles"
+)
+
+// commitCandidate is one generated commit message and the model that wrote it
+type commitCandidate struct {
+	Message string
+	Model   string
+}
+
+// candidateModels assigns a model to each of n candidates, taking turns through
+// candidate_models when it is set and using model for every one otherwise
+func candidateModels(model string, n int) []string {
+	pool := viper.GetStringSlice("candidate_models")
+	if len(pool) == 0 {
+		if model == "" {
+			model = viper.GetString("default_model")
+		}
+		pool = []string{model}
+	}
+
+	models := make([]string, n)
+	for i := range models {
+		models[i] = pool[i%len(pool)]
+	}
+	return models
+}
+
+// candidateTemperature is used when a model writes more than one candidate, so
+// the same prompt does not come back with the same answer
+const candidateTemperature = 1.0
+
+// generateCandidates asks every model for a commit message in parallel. A model
+// asked more than once samples at a higher temperature and is told which
+// alternative it is writing. Identical answers are merged, and the request only
+// fails when no model answered.
+func generateCandidates(apiKey, baseURL string, models []string, data PromptData) ([]commitCandidate, error) {
+	prompt, err := renderPrompt("commit", data)
+	if err != nil {
+		return nil, err
+	}
+	// Redact and check the budget once here so warnings are not repeated per request
+	prompt = redactForAI(prompt)
+	if err := checkBudget(); err != nil {
+		return nil, err
+	}
+
+	results := make([]commitCandidate, len(models))
+	errs := make([]error, len(models))
+	var wg sync.WaitGroup
+	for i, candidateModel := range models {
+		req := AIRequest{APIKey: apiKey, BaseURL: baseURL, Model: candidateModel, Prompt: prompt, Prechecked: true}
+		if count := countModel(models, candidateModel); count > 1 {
+			req.Temperature = candidateTemperature
+			req.Prompt += fmt.Sprintf("\n\nThis is alternative %d of %d. Make it differ from the others in wording or emphasis.", countModel(models[:i], candidateModel)+1, count)
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Not cacheable: the same prompt would return the same answer every time
+			message, err := aiComplete(req)
+			results[i], errs[i] = commitCandidate{Message: message, Model: candidateModel}, err
+		}()
+	}
+	wg.Wait()
+
+	var candidates []commitCandidate
+	for i, candidate := range results {
+		if errs[i] != nil || candidate.Message == "" {
+			continue
+		}
+		if !slices.ContainsFunc(candidates, func(c commitCandidate) bool { return c.Message == candidate.Message }) {
+			candidates = append(candidates, candidate)
+		}
+	}
+	if len(candidates) == 0 {
+		for _, err := range errs {
+			if err != nil {
+				return nil, fmt.Errorf("failed to generate commit message: %w", err)
+			}
+		}
+		return nil, fmt.Errorf("no response from AI model")
+	}
+	return candidates, nil
+}
+
+func countModel(models []string, model string) int {
+	count := 0
+	for _, m := range models {
+		if m == model {
+			count++
+		}
+	}
+	return count
+}
+
+// pickCandidate lets the user choose one of the candidates and edit it before
+// committing. It returns the edited message and the index that was chosen.
+func pickCandidate(title string, candidates []commitCandidate) (string, int, error) {
+	showModel := slices.ContainsFunc(candidates, func(c commitCandidate) bool { return c.Model != candidates[0].Model })
+
+	options := make([]huh.Option[int], len(candidates))
+	for i, candidate := range candidates {
+		label := strings.SplitN(candidate.Message, "\n", 2)[0]
+		if strings.Contains(candidate.Message, "\n") {
+			label += " …"
+		}
+		if showModel && candidate.Model != "" {
+			label += "  " + styles.Muted.Render(candidate.Model)
+		}
+		options[i] = huh.NewOption(label, i)
+	}
+
+	var choice int
+	selectForm := huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[int]().
+				Title(styles.Primary.Render(title)).
+				Options(options...).
+				Value(&choice),
+		),
+	).WithTheme(huh.ThemeCharm())
+	if err := selectForm.Run(); err != nil {
+		return "", 0, fmt.Errorf("error getting user selection: %w", err)
+	}
+
+	message := candidates[choice].Message
+	editForm := huh.NewForm(
+		huh.NewGroup(
+			huh.NewText().
+				Title(styles.Primary.Render("Edit Commit Message")).
+				Description("Adjust the message, then confirm").
+				Lines(6).
+				Value(&message).
+				Validate(func(s string) error {
+					if strings.TrimSpace(s) == "" {
+						return fmt.Errorf("commit message cannot be empty")
+					}
+					return nil
+				}),
+		),
+	).WithTheme(huh.ThemeCharm())
+	if err := editForm.Run(); err != nil {
+		return "", 0, fmt.Errorf("error editing commit message: %w", err)
+	}
+	return strings.TrimSpace(message), choice, nil
+}
+
+// rememberMessages adds messages to the session history, skipping repeats
+func rememberMessages(history []string, messages ...string) []string {
+	for _, message := range messages {
+		if message != "" && !slices.Contains(history, message) {
+			history = append(history, message)
+		}
+	}
+	return history
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestCandidateModels(t *testing.T) {
+	viper.Set("candidate_models", []string{"model-a", "model-b"})
+	t.Cleanup(func() { viper.Set("candidate_models", nil) })
+
+	if got, want := candidateModels("", 3), []string{"model-a", "model-b", "model-a"}; !slices.Equal(got, want) {
+		t.Errorf("candidateModels with candidate_models = %v, want %v", got, want)
+	}
+
+	viper.Set("candidate_models", nil)
+	if got, want := candidateModels("model-c", 2), []string{"model-c", "model-c"}; !slices.Equal(got, want) {
+		t.Errorf("candidateModels without candidate_models = %v, want %v", got, want)
+	}
+}
+
+func TestRememberMessages(t *testing.T) {
+	history := rememberMessages(nil, "feat: a", "", "fix: b")
+	history = rememberMessages(history, "feat: a", "docs: c")
+
+	if want := []string{"feat: a", "fix: b", "docs: c"}; !slices.Equal(history, want) {
+		t.Errorf("history = %v, want %v", history, want)
+	}
+}
+
+func TestGenerateCandidatesVaries(t *testing.T) {
+	var mu sync.Mutex
+	var temperatures []float64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Temperature float64 `json:"temperature"`
+			Messages    []struct {
+				Content string `json:"content"`
+			} `json:"messages"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decoding request: %v", err)
+		}
+		mu.Lock()
+		temperatures = append(temperatures, body.Temperature)
+		mu.Unlock()
+
+		// Answer with the prompt's last line so only differing prompts give differing messages
+		prompt := body.Messages[0].Content
+		content, _ := json.Marshal("feat: " + prompt[strings.LastIndex(prompt, "\n")+1:])
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"id": "1", "object": "chat.completion", "model": "m", "choices": [{"index": 0, "finish_reason": "stop", "message": {"role": "assistant", "content": %s}}]}`, content)
+	}))
+	defer server.Close()
+	t.Setenv("HOME", t.TempDir())
+
+	candidates, err := generateCandidates("test", server.URL, []string{"model-a", "model-a", "model-a"}, PromptData{Diff: "+x"})
+	if err != nil {
+		t.Fatalf("generateCandidates() error = %v", err)
+	}
+	if len(candidates) != 3 {
+		t.Errorf("got %d candidates, want 3 distinct ones: %+v", len(candidates), candidates)
+	}
+	for _, temperature := range temperatures {
+		if temperature != candidateTemperature {
+			t.Errorf("temperature = %v, want %v for a repeated model", temperature, candidateTemperature)
+		}
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"os"
 	"os/exec"
 
-	"github.com/charmbracelet/huh"
 	"github.com/spf13/cobra"
 
 	"github.com/aixoio/tt/styles"
@@ -15,7 +14,7 @@ var commitCmd = &cobra.Command{
 	Use:     "c [message]",
 	Aliases: []string{"commit"},
 	Short:   "Commit changes with style",
-	Long:    styles.Info.Render("Commit changes to git with an interactive prompt for commit messages. Supports automatic file staging and pushing."),
+	Long:    styles.Info.Render("Commit changes to git with an interactive prompt for commit messages. Without -m the message is written in a textarea, or $EDITOR when message_editor is \"editor\", with a summary of the staged changes. Supports automatic file staging and pushing."),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		message, _ := cmd.Flags().GetString("message")
 		addFlag, _ := cmd.Flags().GetBool("add")
@@ -39,6 +38,18 @@ var commitCmd = &cobra.Command{
 			fmt.Println(styles.SuccessIcon)
 		}
 
+		allowSecrets, _ := cmd.Flags().GetBool("allow-secrets")
+		if err := checkStagedSecrets(allowSecrets); err != nil {
+			return err
+		}
+
+		pair, _ := cmd.Flags().GetStringSlice("pair")
+		pickCoAuthors, _ := cmd.Flags().GetBool("co-authors")
+		coAuthors, err := resolveCoAuthors(pair, pickCoAuthors)
+		if err != nil {
+			return err
+		}
+
 		// Get commit message
 		if message == "" {
 			// Show current status before prompting
@@ -50,26 +61,15 @@ var commitCmd = &cobra.Command{
 				))
 			}
 
-			// Create styled input form
-			form := huh.NewForm(
-				huh.NewGroup(
-					huh.NewInput().
-						Title(styles.Primary.Render("Commit Message")).
-						Placeholder("Describe your changes...").
-						Description("Write a clear, concise commit message").
-						Value(&message).
-						Validate(func(s string) error {
-							if len(s) < 3 {
-								return fmt.Errorf("commit message too short")
-							}
-							return nil
-						}),
-				),
-			).WithTheme(huh.ThemeCharm())
-
-			if err := form.Run(); err != nil {
-				return fmt.Errorf("failed to get commit message: %w", err)
+			edited, keep, err := editAndValidate("")
+			if err != nil {
+				return err
+			}
+			if !keep {
+				fmt.Println(styles.InfoIcon + " " + styles.Info.Render("Commit canceled"))
+				return nil
 			}
+			message = edited
 		}
 
 		if message == "" {
@@ -77,13 +77,18 @@ var commitCmd = &cobra.Command{
 			return fmt.Errorf("commit message cannot be empty")
 		}
 
+		message, err = decorateMessage(message, coAuthors)
+		if err != nil {
+			return err
+		}
+
 		// Show commit details
 		fmt.Println()
 		fmt.Println(styles.InfoIcon + " " + styles.Info.Render("Committing with message: ") + styles.Highlight.Render("\""+message+"\""))
 
 		// Execute commit
 		fmt.Print(styles.Spinner.Render("⏳") + " " + styles.Info.Render("Creating commit...\n"))
-		gitCmd := exec.Command("git", "commit", "-m", message)
+		gitCmd := exec.Command("git", withSigning(shouldSign(cmd, "sign_commits"), "-S", "commit", "-m", message)...)
 		gitCmd.Stdout = os.Stdout
 		gitCmd.Stderr = os.Stderr
 
@@ -117,4 +122,8 @@ func init() {
 	commitCmd.Flags().StringP("message", "m", "", "Commit message")
 	commitCmd.Flags().BoolP("add", "a", false, "Add all files before committing")
 	commitCmd.Flags().BoolP("push", "p", false, "Push after committing")
+	commitCmd.Flags().BoolP("sign", "S", false, "Sign the commit with GPG or SSH (overrides sign_commits from config)")
+	commitCmd.Flags().StringSlice("pair", nil, "Add a co-author by team roster alias or \"Name <email>\" (repeatable)")
+	commitCmd.Flags().Bool("co-authors", false, "Pick co-authors from the team roster and recent authors")
+	commitCmd.Flags().Bool("allow-secrets", false, "Commit even if the secret scanner finds something")
 }
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"regexp"
+	"strings"
+)
+
+// ConventionalCommit holds the parts of a commit message that follows the
+// Conventional Commits format (type(scope)!: subject).
+type ConventionalCommit struct {
+	Type     string
+	Scope    string
+	Subject  string
+	Body     string
+	Breaking bool
+}
+
+var conventionalHeaderRe = regexp.MustCompile(`^([a-zA-Z]+)(?:\(([^)]*)\))?(!)?:\s*(.+)$`)
+
+// parseConventionalCommit parses a commit subject and body. The second return
+// value is false when the subject does not follow the conventional format.
+func parseConventionalCommit(subject, body string) (ConventionalCommit, bool) {
+	subject = strings.TrimSpace(subject)
+	commit := ConventionalCommit{Subject: subject, Body: strings.TrimSpace(body)}
+
+	matches := conventionalHeaderRe.FindStringSubmatch(subject)
+	if matches == nil {
+		return commit, false
+	}
+
+	commit.Type = strings.ToLower(matches[1])
+	commit.Scope = matches[2]
+	commit.Breaking = matches[3] == "!"
+	commit.Subject = matches[4]
+
+	for line := range strings.SplitSeq(commit.Body, "\n") {
+		if strings.HasPrefix(line, "BREAKING CHANGE:") || strings.HasPrefix(line, "BREAKING-CHANGE:") {
+			commit.Breaking = true
+			break
+		}
+	}
+
+	return commit, true
+}
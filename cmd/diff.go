@@ -1,15 +1,11 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
 	"os/exec"
-	"path/filepath"
 	"strings"
 
 	"github.com/charmbracelet/glamour"
-	"github.com/openai/openai-go/v3"
-	"github.com/openai/openai-go/v3/option"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
@@ -17,28 +13,35 @@ import (
 )
 
 var (
-	aiFlag   bool
-	statFlag bool
-	nameOnly bool
+	aiFlag         bool
+	statFlag       bool
+	nameOnly       bool
+	sideBySideFlag bool
+	wordFlag       bool
+	noPagerFlag    bool
+	noSyntaxFlag   bool
 )
 
 var diffCmd = &cobra.Command{
-	Use:     "diff",
+	Use:     "diff [args] [-- <path>...]",
 	Aliases: []string{"d"},
 	Short:   "Show changes between commits",
-	Long:    styles.Info.Render("Display the differences between commits or working tree, with optional AI summary and enhanced styling."),
+	Long:    styles.Info.Render("Display the differences between commits or working tree, staged changes, a single commit or everything on the current branch, with optional AI summary and enhanced styling."),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		repoCmd := exec.Command("git", "rev-parse", "--is-inside-work-tree")
 		if err := repoCmd.Run(); err != nil {
 			return fmt.Errorf("not a git repository: %w", err)
 		}
 
-		fullDiffCmd := exec.Command("git", append([]string{"diff", "--no-color"}, args...)...)
-		fullDiffOutput, err := fullDiffCmd.Output()
+		mode, err := diffModeFromFlags(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		diffContent, err := mode.output("--no-color")
 		if err != nil {
 			return fmt.Errorf("failed to get git diff: %w", err)
 		}
-		diffContent := string(fullDiffOutput)
 
 		if diffContent == "" {
 			fmt.Println(styles.SuccessIcon + " " + styles.Success.Render("No changes to display"))
@@ -46,48 +49,40 @@ var diffCmd = &cobra.Command{
 		}
 
 		fmt.Println(styles.Header.Render("Git Diff"))
+		if description := mode.description(); description != "" {
+			fmt.Println(styles.Info.Render(description))
+		}
 		fmt.Println()
 
 		if statFlag {
-			statCmd := exec.Command("git", append([]string{"diff", "--stat"}, args...)...)
-			statOutput, err := statCmd.Output()
+			statOutput, err := mode.output("--stat")
 			if err != nil {
 				fmt.Println(styles.ErrorIcon + " " + styles.Error.Render("Failed to get diff stat"))
 			} else {
 				fmt.Print(styles.Info.Render("Changes summary:\n"))
-				fmt.Println(string(statOutput))
+				fmt.Println(statOutput)
 				fmt.Println()
 			}
 		}
 
 		if nameOnly {
-			nameCmd := exec.Command("git", append([]string{"diff", "--name-only"}, args...)...)
-			nameOutput, err := nameCmd.Output()
+			names, err := mode.changedFiles()
 			if err != nil {
 				return fmt.Errorf("failed to get file names: %w", err)
 			}
-			names := strings.Split(strings.TrimSpace(string(nameOutput)), "\n")
 			for _, name := range names {
-				if name != "" {
-					fmt.Println("  " + styles.FilePath.Render(name))
-				}
+				fmt.Println("  " + styles.FilePath.Render(name))
 			}
 			return nil
 		}
 
-		lines := strings.Split(diffContent, "\n")
-		for _, line := range lines {
-			if strings.HasPrefix(line, "diff --git") || strings.HasPrefix(line, "index") || strings.HasPrefix(line, "@@") {
-				fmt.Println(styles.DiffHeader.Render(line))
-			} else if strings.HasPrefix(line, "+++") || strings.HasPrefix(line, "---") {
-				fmt.Println(styles.DiffHeader.Render(line))
-			} else if strings.HasPrefix(line, "+") {
-				fmt.Println(styles.Add.Render(line))
-			} else if strings.HasPrefix(line, "-") {
-				fmt.Println(styles.Del.Render(line))
-			} else {
-				fmt.Println(styles.Neutral.Render(line))
-			}
+		renderOpts := diffRenderOptions{SideBySide: sideBySideFlag, Word: wordFlag}
+		if !noSyntaxFlag {
+			renderOpts.Syntax = syntaxHighlighterFromConfig()
+		}
+		rendered := renderDiffWith(diffContent, renderOpts)
+		if err := showOutput("Git Diff", rendered, !noPagerFlag); err != nil {
+			return err
 		}
 
 		if aiFlag {
@@ -103,80 +98,17 @@ var diffCmd = &cobra.Command{
 					model = "google/gemini-2.5-flash-lite"
 				}
 
-				// Inline getProjectInfo
-				projectInfoStr := ""
-				files, err := filepath.Glob("*")
-				if err != nil {
-					fmt.Printf("%s Warning: couldn't get project info: %v\n", styles.WarningIcon, err)
-				} else {
-					var projectInfo strings.Builder
-					projectInfo.WriteString("Project files include: ")
-
-					hasGoMod := false
-					hasPackageJSON := false
-					hasPomXML := false
-					hasCMake := false
-					hasPyProject := false
-
-					for _, file := range files {
-						switch file {
-						case "go.mod":
-							hasGoMod = true
-						case "package.json":
-							hasPackageJSON = true
-						case "pom.xml":
-							hasPomXML = true
-						case "CMakeLists.txt":
-							hasCMake = true
-						case "pyproject.toml":
-							hasPyProject = true
-						}
-					}
-
-					if hasGoMod {
-						projectInfo.WriteString("Go project. ")
-					}
-					if hasPackageJSON {
-						projectInfo.WriteString("JavaScript/Node.js project. ")
-					}
-					if hasPomXML {
-						projectInfo.WriteString("Java/Maven project. ")
-					}
-					if hasCMake {
-						projectInfo.WriteString("C/C++ project with CMake. ")
-					}
-					if hasPyProject {
-						projectInfo.WriteString("Python project. ")
-					}
-					projectInfoStr = projectInfo.String()
-				}
-
-				// Inline getChangedFiles
-				changedFiles := []string{}
-				changedCmd := exec.Command("git", append([]string{"diff", "--name-only"}, args...)...)
-				changedOutput, err := changedCmd.Output()
+				changedFiles, err := mode.changedFiles()
 				if err != nil {
 					fmt.Printf("%s Warning: couldn't get changed files: %v\n", styles.WarningIcon, err)
-				} else {
-					namesList := strings.Split(strings.TrimSpace(string(changedOutput)), "\n")
-					for _, n := range namesList {
-						if n != "" {
-							changedFiles = append(changedFiles, n)
-						}
-					}
 				}
 
-				// Build prompt for markdown
-				var sb strings.Builder
-				sb.WriteString("Provide a concise markdown-formatted summary (2-3 sentences, use **bold** for key changes, - bullets for files/features) of the code changes in this git diff. Focus on what the changes achieve, such as new features, bug fixes, or refactors. Only respond with the markdown summary, nothing else.\n\n")
-				if projectInfoStr != "" {
-					sb.WriteString(projectInfoStr + "\n\n")
-				}
-				if len(changedFiles) > 0 {
-					sb.WriteString(fmt.Sprintf("Changed files: %s\n\n", strings.Join(changedFiles, ", ")))
+				promptData := newPromptData(diffContent, changedFiles)
+				promptData.Description = mode.description()
+				basePrompt, err := renderPrompt("diff", promptData)
+				if err != nil {
+					return err
 				}
-				sb.WriteString("Changes:\n" + diffContent)
-				basePrompt := sb.String()
 
 				fmt.Printf("%s %s: %s\n\n", styles.InfoIcon, styles.Info.Render("Using model"), styles.Highlight.Render(model))
 
@@ -190,12 +122,7 @@ var diffCmd = &cobra.Command{
 					fmt.Println(styles.Info.Render("🤖 AI Overview:"))
 					fmt.Println()
 
-					rendered, err := glamour.Render(summary, "dark")
-					if err != nil {
-						fmt.Println(styles.Info.Render(summary)) // Fallback
-					} else {
-						fmt.Print(rendered)
-					}
+					printMarkdown(summary)
 					fmt.Println()
 				}
 			}
@@ -205,31 +132,34 @@ var diffCmd = &cobra.Command{
 	},
 }
 
-func generateAIResponse(apiKey, baseURL, model, prompt string) (string, error) {
-	if model == "" {
-		model = viper.GetString("default_model")
+// renderDiff colours a unified diff line by line
+func renderDiff(diffContent string) string {
+	lines := strings.Split(diffContent, "\n")
+	styled := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if strings.HasPrefix(line, "diff --git") || strings.HasPrefix(line, "index") || strings.HasPrefix(line, "@@") {
+			styled = append(styled, styles.DiffHeader.Render(line))
+		} else if strings.HasPrefix(line, "+++") || strings.HasPrefix(line, "---") {
+			styled = append(styled, styles.DiffHeader.Render(line))
+		} else if strings.HasPrefix(line, "+") {
+			styled = append(styled, styles.Add.Render(line))
+		} else if strings.HasPrefix(line, "-") {
+			styled = append(styled, styles.Del.Render(line))
+		} else {
+			styled = append(styled, styles.Neutral.Render(line))
+		}
 	}
+	return strings.Join(styled, "\n")
+}
 
-	client := openai.NewClient(
-		option.WithBaseURL(baseURL),
-		option.WithHeader("HTTP-Referer", "https://github.com/aixoio/tt"),
-		option.WithHeader("X-Title", "tt"),
-		option.WithAPIKey(apiKey),
-	)
-
-	resp, err := client.Chat.Completions.New(context.Background(), openai.ChatCompletionNewParams{
-		Model:    model,
-		Messages: []openai.ChatCompletionMessageParamUnion{openai.UserMessage(prompt)},
-	})
+// printMarkdown renders markdown with glamour, falling back to plain styled text
+func printMarkdown(markdown string) {
+	rendered, err := glamour.Render(markdown, "dark")
 	if err != nil {
-		return "", fmt.Errorf("failed to generate AI response: %w", err)
+		fmt.Println(styles.Info.Render(markdown)) // Fallback
+		return
 	}
-
-	if len(resp.Choices) == 0 {
-		return "", fmt.Errorf("no response from AI model")
-	}
-
-	return strings.TrimSpace(resp.Choices[0].Message.Content), nil
+	fmt.Print(rendered)
 }
 
 func init() {
@@ -237,4 +167,13 @@ func init() {
 	diffCmd.Flags().BoolVarP(&aiFlag, "ai", "a", false, "Generate AI-powered overview of changes")
 	diffCmd.Flags().BoolVarP(&statFlag, "stat", "s", false, "Show stat summary")
 	diffCmd.Flags().BoolVarP(&nameOnly, "name-only", "n", false, "Show only names of changed files")
+	diffCmd.Flags().BoolVarP(&sideBySideFlag, "side-by-side", "y", false, "Show old and new versions in side-by-side columns")
+	diffCmd.Flags().BoolVarP(&wordFlag, "word", "w", false, "Highlight changed words within modified lines")
+	diffCmd.Flags().BoolVarP(&noPagerFlag, "no-pager", "P", false, "Print the diff without paging it")
+	diffCmd.Flags().Bool("staged", false, "Show staged changes instead of the working tree")
+	diffCmd.Flags().String("commit", "", "Show the changes made by a single commit")
+	diffCmd.Flags().String("branch", "", "Show what this branch adds since its merge-base with a base branch (default: the repository's default branch; use --branch=<base>)")
+	diffCmd.Flags().Lookup("branch").NoOptDefVal = defaultBaseFlag
+	diffCmd.MarkFlagsMutuallyExclusive("staged", "commit", "branch")
+	diffCmd.Flags().BoolVar(&noSyntaxFlag, "no-syntax", false, "Disable syntax highlighting of code lines")
 }
@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// defaultBaseFlag is the value --branch takes when no base is given
+const defaultBaseFlag = "@default"
+
+// diffMode selects which changes tt diff shows. The zero value is the
+// working tree compared with the index, like plain git diff.
+type diffMode struct {
+	Staged bool
+	// Commit shows a single commit against its parent, like git show
+	Commit string
+	// Base and MergeBase compare HEAD with its merge-base with Base
+	Base      string
+	MergeBase string
+	Args      []string
+}
+
+// refGivenAsPath returns the first arg before any "--" that names a commit
+// rather than an existing path
+func refGivenAsPath(cmd *cobra.Command, args []string) string {
+	if dash := cmd.ArgsLenAtDash(); dash >= 0 {
+		args = args[:dash]
+	}
+	for _, arg := range args {
+		if _, err := os.Stat(arg); err == nil {
+			continue
+		}
+		if exec.Command("git", "rev-parse", "--verify", "--quiet", arg+"^{commit}").Run() == nil {
+			return arg
+		}
+	}
+	return ""
+}
+
+// diffModeFromFlags reads --staged, --commit and --branch. In commit and
+// branch mode the remaining args are treated as paths.
+func diffModeFromFlags(cmd *cobra.Command, args []string) (diffMode, error) {
+	mode := diffMode{Args: args}
+	mode.Staged, _ = cmd.Flags().GetBool("staged")
+	mode.Commit, _ = cmd.Flags().GetString("commit")
+
+	if !cmd.Flags().Changed("branch") {
+		return mode, nil
+	}
+
+	base, _ := cmd.Flags().GetString("branch")
+	if base == defaultBaseFlag {
+		// "--branch main" leaves main as an arg, since the flag's value is optional
+		if ref := refGivenAsPath(cmd, args); ref != "" {
+			return mode, fmt.Errorf("'%s' is a branch, not a path: write --branch=%s to compare against it", ref, ref)
+		}
+		defaultBranch, err := getDefaultBranch()
+		if err != nil {
+			return mode, err
+		}
+		base = defaultBranch
+	}
+
+	mergeBase, err := getMergeBase(base, "HEAD")
+	if err != nil {
+		return mode, err
+	}
+	mode.Base, mode.MergeBase = base, mergeBase
+	return mode, nil
+}
+
+// gitArgs builds the git command for this mode with extra output flags such as --stat
+func (m diffMode) gitArgs(extra ...string) []string {
+	switch {
+	case m.Commit != "":
+		args := append([]string{"show", "--format="}, extra...)
+		return append(append(args, m.Commit, "--"), m.Args...)
+	case m.MergeBase != "":
+		args := append([]string{"diff"}, extra...)
+		return append(append(args, m.MergeBase, "HEAD", "--"), m.Args...)
+	case m.Staged:
+		args := append([]string{"diff", "--cached"}, extra...)
+		return append(args, m.Args...)
+	default:
+		return append(append([]string{"diff"}, extra...), m.Args...)
+	}
+}
+
+// output runs git for this mode and returns its output
+func (m diffMode) output(extra ...string) (string, error) {
+	output, err := exec.Command("git", m.gitArgs(extra...)...).Output()
+	if err != nil {
+		return "", err
+	}
+	return string(output), nil
+}
+
+// changedFiles returns the paths touched in this mode
+func (m diffMode) changedFiles() ([]string, error) {
+	output, err := m.output("--name-only")
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for name := range strings.SplitSeq(strings.TrimSpace(output), "\n") {
+		if name != "" {
+			files = append(files, name)
+		}
+	}
+	return files, nil
+}
+
+// description says which changes are shown, for headers and AI prompts. It is
+// empty when plain git diff arguments decide what is compared.
+func (m diffMode) description() string {
+	switch {
+	case m.Commit != "":
+		if details, err := getCommitDetails(m.Commit); err == nil {
+			return fmt.Sprintf("Commit %s: %s (%s, %s)", details.Hash[:min(7, len(details.Hash))], details.Message, details.Author, details.Date)
+		}
+		return "Commit " + m.Commit
+	case m.MergeBase != "":
+		return fmt.Sprintf("Changes on this branch since its merge-base with %s (%s)", m.Base, m.MergeBase[:min(7, len(m.MergeBase))])
+	case m.Staged:
+		return "Staged changes"
+	case len(m.Args) == 0:
+		return "Unstaged changes"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,365 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/aixoio/tt/styles"
+)
+
+// DiffFile is one file section of a unified diff
+type DiffFile struct {
+	OldPath string
+	NewPath string
+	Header  []string
+	Hunks   []DiffHunk
+}
+
+// DiffHunk is a single @@ section of a file diff
+type DiffHunk struct {
+	Header   string
+	OldStart int
+	NewStart int
+	Lines    []string
+}
+
+// diffBlock is either a context line or a run of removed lines followed by added lines
+type diffBlock struct {
+	Context  string
+	Dels     []string
+	Adds     []string
+	OldStart int
+	NewStart int
+	IsChange bool
+}
+
+// diffRenderOptions selects how a diff is laid out
+type diffRenderOptions struct {
+	SideBySide bool
+	Word       bool
+	Width      int
+	Syntax     *syntaxHighlighter
+}
+
+var hunkHeaderRe = regexp.MustCompile(`^@@ -(\d+)(?:,\d+)? \+(\d+)(?:,\d+)? @@`)
+
+// maxWordDiffCells bounds the token LCS table so very long lines fall back to whole-line highlighting
+const maxWordDiffCells = 40000
+
+// parseUnifiedDiff splits git diff output into files and hunks
+func parseUnifiedDiff(diff string) []DiffFile {
+	var files []DiffFile
+	var file *DiffFile
+	inHunk := false
+
+	for _, line := range strings.Split(strings.TrimRight(diff, "\n"), "\n") {
+		switch {
+		case strings.HasPrefix(line, "diff --git ") || strings.HasPrefix(line, "diff --cc ") || strings.HasPrefix(line, "diff --combined "):
+			files = append(files, DiffFile{Header: []string{line}})
+			file = &files[len(files)-1]
+			file.OldPath, file.NewPath = parseDiffGitPaths(line)
+			inHunk = false
+		case file == nil:
+			continue
+		case strings.HasPrefix(line, "@@"):
+			hunk := DiffHunk{Header: line}
+			if matches := hunkHeaderRe.FindStringSubmatch(line); matches != nil {
+				hunk.OldStart, _ = strconv.Atoi(matches[1])
+				hunk.NewStart, _ = strconv.Atoi(matches[2])
+			}
+			file.Hunks = append(file.Hunks, hunk)
+			inHunk = true
+		case inHunk:
+			last := &file.Hunks[len(file.Hunks)-1]
+			last.Lines = append(last.Lines, line)
+		default:
+			file.Header = append(file.Header, line)
+			if path, found := strings.CutPrefix(line, "--- a/"); found {
+				file.OldPath = path
+			} else if path, found := strings.CutPrefix(line, "+++ b/"); found {
+				file.NewPath = path
+			}
+		}
+	}
+
+	return files
+}
+
+// parseDiffGitPaths extracts the a/ and b/ paths from a "diff --git" line
+func parseDiffGitPaths(line string) (string, string) {
+	rest := strings.TrimPrefix(line, "diff --git ")
+	if index := strings.LastIndex(rest, " b/"); index != -1 && strings.HasPrefix(rest, "a/") {
+		return rest[2:index], rest[index+3:]
+	}
+	// Combined diffs only name the file once
+	fields := strings.Fields(line)
+	path := fields[len(fields)-1]
+	return path, path
+}
+
+// Path returns the file's current path, or its old path if it was deleted
+func (f DiffFile) Path() string {
+	if f.NewPath != "" && f.NewPath != "/dev/null" {
+		return f.NewPath
+	}
+	return f.OldPath
+}
+
+// groupHunkLines groups a hunk's lines into context lines and change blocks
+func groupHunkLines(hunk DiffHunk) []diffBlock {
+	var blocks []diffBlock
+	oldNo, newNo := hunk.OldStart, hunk.NewStart
+
+	for _, line := range hunk.Lines {
+		if line == "" {
+			line = " "
+		}
+		switch line[0] {
+		case '-', '+':
+			if len(blocks) == 0 || !blocks[len(blocks)-1].IsChange ||
+				(line[0] == '-' && len(blocks[len(blocks)-1].Adds) > 0) {
+				blocks = append(blocks, diffBlock{IsChange: true, OldStart: oldNo, NewStart: newNo})
+			}
+			block := &blocks[len(blocks)-1]
+			if line[0] == '-' {
+				block.Dels = append(block.Dels, line[1:])
+				oldNo++
+			} else {
+				block.Adds = append(block.Adds, line[1:])
+				newNo++
+			}
+		case '\\':
+			// "\ No newline at end of file" belongs to the previous line, keep it as context
+			blocks = append(blocks, diffBlock{Context: line, OldStart: -1, NewStart: -1})
+		default:
+			blocks = append(blocks, diffBlock{Context: line[1:], OldStart: oldNo, NewStart: newNo})
+			oldNo++
+			newNo++
+		}
+	}
+
+	return blocks
+}
+
+// renderDiffWith renders a diff in the unified, word-level or side-by-side layout,
+// optionally with syntax highlighting
+func renderDiffWith(diffContent string, opts diffRenderOptions) string {
+	if !opts.SideBySide && !opts.Word && opts.Syntax == nil {
+		return renderDiff(diffContent)
+	}
+
+	var out []string
+	for _, file := range parseUnifiedDiff(diffContent) {
+		for _, line := range file.Header {
+			out = append(out, styles.DiffHeader.Render(line))
+		}
+		for _, hunk := range file.Hunks {
+			out = append(out, styles.DiffHeader.Render(hunk.Header))
+			if opts.SideBySide {
+				out = append(out, renderHunkSideBySide(hunk, file.Path(), opts)...)
+			} else {
+				out = append(out, renderHunkUnified(hunk, file.Path(), opts)...)
+			}
+		}
+	}
+
+	return strings.Join(out, "\n")
+}
+
+// renderHunkUnified renders a hunk in unified layout, with intra-line
+// highlights for paired lines when opts.Word is set
+func renderHunkUnified(hunk DiffHunk, path string, opts diffRenderOptions) []string {
+	var out []string
+	for _, block := range groupHunkLines(hunk) {
+		if !block.IsChange {
+			out = append(out, renderContextLine(block, path, opts.Syntax))
+			continue
+		}
+
+		dels := make([]string, len(block.Dels))
+		adds := make([]string, len(block.Adds))
+		for i := range block.Dels {
+			dels[i] = styleDiffLine(opts.Syntax, path, '-', block.Dels[i])
+		}
+		for i := range block.Adds {
+			adds[i] = styleDiffLine(opts.Syntax, path, '+', block.Adds[i])
+		}
+		for i := 0; opts.Word && i < len(dels) && i < len(adds); i++ {
+			dels[i], adds[i] = wordDiff(block.Dels[i], block.Adds[i])
+		}
+
+		for _, line := range dels {
+			out = append(out, styles.Del.Render("-")+line)
+		}
+		for _, line := range adds {
+			out = append(out, styles.Add.Render("+")+line)
+		}
+	}
+	return out
+}
+
+func renderContextLine(block diffBlock, path string, syntax *syntaxHighlighter) string {
+	if block.OldStart < 0 {
+		return styles.Muted.Render(block.Context)
+	}
+	return styles.Neutral.Render(" ") + styleDiffLine(syntax, path, ' ', block.Context)
+}
+
+// renderHunkSideBySide lays a hunk out as old and new columns
+func renderHunkSideBySide(hunk DiffHunk, path string, opts diffRenderOptions) []string {
+	width := opts.Width
+	if width <= 0 {
+		width = terminalWidth()
+	}
+	colWidth := max((width-3)/2, 20)
+	separator := styles.DiffGutter.Render(" │ ")
+
+	var out []string
+	for _, block := range groupHunkLines(hunk) {
+		if !block.IsChange {
+			if block.OldStart < 0 {
+				out = append(out, styles.Muted.Render(block.Context))
+				continue
+			}
+			text := styleDiffLine(opts.Syntax, path, ' ', expandTabs(block.Context))
+			out = append(out, sideCell(block.OldStart, " ", text, colWidth)+separator+sideCell(block.NewStart, " ", text, colWidth))
+			continue
+		}
+
+		rows := max(len(block.Dels), len(block.Adds))
+		for i := range rows {
+			left, right := strings.Repeat(" ", colWidth), strings.Repeat(" ", colWidth)
+
+			var oldText, newText string
+			hasOld, hasNew := i < len(block.Dels), i < len(block.Adds)
+			if hasOld {
+				oldText = styleDiffLine(opts.Syntax, path, '-', expandTabs(block.Dels[i]))
+			}
+			if hasNew {
+				newText = styleDiffLine(opts.Syntax, path, '+', expandTabs(block.Adds[i]))
+			}
+			if hasOld && hasNew && opts.Word {
+				oldText, newText = wordDiff(expandTabs(block.Dels[i]), expandTabs(block.Adds[i]))
+			}
+
+			if hasOld {
+				left = sideCell(block.OldStart+i, styles.Del.Render("-"), oldText, colWidth)
+			}
+			if hasNew {
+				right = sideCell(block.NewStart+i, styles.Add.Render("+"), newText, colWidth)
+			}
+			out = append(out, left+separator+right)
+		}
+	}
+	return out
+}
+
+// sideCell renders one column of a side-by-side row, truncated and padded to width
+func sideCell(lineNo int, sign, text string, width int) string {
+	gutter := styles.DiffGutter.Render(fmt.Sprintf("%4d ", lineNo))
+	cell := gutter + sign + ansi.Truncate(text, max(width-6, 1), "…")
+	if padding := width - lipgloss.Width(cell); padding > 0 {
+		cell += strings.Repeat(" ", padding)
+	}
+	return cell
+}
+
+func expandTabs(s string) string {
+	return strings.ReplaceAll(s, "\t", "    ")
+}
+
+// tokenizeWords splits a line into words, whitespace runs and single punctuation characters
+func tokenizeWords(s string) []string {
+	var tokens []string
+	runes := []rune(s)
+	for i := 0; i < len(runes); {
+		j := i + 1
+		switch {
+		case isWordRune(runes[i]):
+			for j < len(runes) && isWordRune(runes[j]) {
+				j++
+			}
+		case unicode.IsSpace(runes[i]):
+			for j < len(runes) && unicode.IsSpace(runes[j]) {
+				j++
+			}
+		}
+		tokens = append(tokens, string(runes[i:j]))
+		i = j
+	}
+	return tokens
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// diffTokens marks which tokens of a and b are part of their longest common subsequence
+func diffTokens(a, b []string) ([]bool, []bool) {
+	keepA, keepB := make([]bool, len(a)), make([]bool, len(b))
+	if len(a)*len(b) > maxWordDiffCells {
+		return keepA, keepB
+	}
+
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] == b[j]:
+			keepA[i], keepB[j] = true, true
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return keepA, keepB
+}
+
+// wordDiff renders a removed/added line pair with the changed words highlighted
+func wordDiff(oldLine, newLine string) (string, string) {
+	oldTokens, newTokens := tokenizeWords(oldLine), tokenizeWords(newLine)
+	keepOld, keepNew := diffTokens(oldTokens, newTokens)
+	return renderWordTokens(oldTokens, keepOld, styles.Del, styles.DelHighlight),
+		renderWordTokens(newTokens, keepNew, styles.Add, styles.AddHighlight)
+}
+
+// renderWordTokens joins tokens, styling runs of kept and changed tokens separately
+func renderWordTokens(tokens []string, keep []bool, plain, highlight lipgloss.Style) string {
+	var sb strings.Builder
+	for i := 0; i < len(tokens); {
+		j := i
+		var run strings.Builder
+		for j < len(tokens) && keep[j] == keep[i] {
+			run.WriteString(tokens[j])
+			j++
+		}
+		if keep[i] {
+			sb.WriteString(plain.Render(run.String()))
+		} else {
+			sb.WriteString(highlight.Render(run.String()))
+		}
+		i = j
+	}
+	return sb.String()
+}
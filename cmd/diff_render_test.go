@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+const sampleDiff = `diff --git a/main.go b/main.go
+index 961680a..7f46a6f 100644
+--- a/main.go
++++ b/main.go
+@@ -1,4 +1,5 @@
+ func main() {
+-	x := 1
++	y := 2
++	z := 3
+ }
+`
+
+func TestParseUnifiedDiff(t *testing.T) {
+	files := parseUnifiedDiff(sampleDiff)
+	if len(files) != 1 {
+		t.Fatalf("expected 1 file, got %d", len(files))
+	}
+	if files[0].Path() != "main.go" {
+		t.Errorf("expected path main.go, got %q", files[0].Path())
+	}
+	if len(files[0].Hunks) != 1 || files[0].Hunks[0].NewStart != 1 || len(files[0].Hunks[0].Lines) != 5 {
+		t.Errorf("unexpected hunks %+v", files[0].Hunks)
+	}
+
+	blocks := groupHunkLines(files[0].Hunks[0])
+	if len(blocks) != 3 || !blocks[1].IsChange || len(blocks[1].Dels) != 1 || len(blocks[1].Adds) != 2 {
+		t.Errorf("unexpected blocks %+v", blocks)
+	}
+}
+
+func TestDiffTokens(t *testing.T) {
+	oldTokens := tokenizeWords(`fmt.Println("hello world")`)
+	newTokens := tokenizeWords(`fmt.Println("hello there world")`)
+	keepOld, keepNew := diffTokens(oldTokens, newTokens)
+
+	for i, keep := range keepOld {
+		if !keep {
+			t.Errorf("expected old token %q to be unchanged", oldTokens[i])
+		}
+	}
+
+	var changed []string
+	for i, keep := range keepNew {
+		if !keep {
+			changed = append(changed, newTokens[i])
+		}
+	}
+	if strings.Join(changed, "") != "there " && strings.Join(changed, "") != " there" {
+		t.Errorf("expected only 'there' to change, got %q", changed)
+	}
+}
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/lexers"
+	chromastyles "github.com/alecthomas/chroma/v2/styles"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/viper"
+
+	"github.com/aixoio/tt/styles"
+)
+
+// defaultDiffTheme is the chroma style used when diff_theme is not configured
+const defaultDiffTheme = "monokai"
+
+// syntaxHighlighter colours diff lines using the chroma lexer for each file's language
+type syntaxHighlighter struct {
+	style   *chroma.Style
+	lexers  map[string]chroma.Lexer
+	palette map[chroma.TokenType]lipgloss.Style
+}
+
+// newSyntaxHighlighter returns a highlighter for the named chroma theme
+func newSyntaxHighlighter(theme string) *syntaxHighlighter {
+	return &syntaxHighlighter{
+		style:   chromastyles.Get(theme),
+		lexers:  map[string]chroma.Lexer{},
+		palette: map[chroma.TokenType]lipgloss.Style{},
+	}
+}
+
+// syntaxHighlighterFromConfig returns the configured highlighter, or nil when
+// diff_theme is set to "none"
+func syntaxHighlighterFromConfig() *syntaxHighlighter {
+	theme := viper.GetString("diff_theme")
+	if theme == "" {
+		theme = defaultDiffTheme
+	}
+	if theme == "none" {
+		return nil
+	}
+	return newSyntaxHighlighter(theme)
+}
+
+// lexerFor returns the lexer matching a path, or nil for unknown languages
+func (h *syntaxHighlighter) lexerFor(path string) chroma.Lexer {
+	if lexer, found := h.lexers[path]; found {
+		return lexer
+	}
+	lexer := lexers.Match(path)
+	if lexer != nil {
+		lexer = chroma.Coalesce(lexer)
+	}
+	h.lexers[path] = lexer
+	return lexer
+}
+
+// tokenStyle converts the theme entry for a token type into a lipgloss style
+func (h *syntaxHighlighter) tokenStyle(tokenType chroma.TokenType) lipgloss.Style {
+	if style, found := h.palette[tokenType]; found {
+		return style
+	}
+	entry := h.style.Get(tokenType)
+	style := lipgloss.NewStyle()
+	if entry.Colour.IsSet() {
+		style = style.Foreground(lipgloss.Color(entry.Colour.String()))
+	}
+	if entry.Bold == chroma.Yes {
+		style = style.Bold(true)
+	}
+	if entry.Italic == chroma.Yes {
+		style = style.Italic(true)
+	}
+	if entry.Underline == chroma.Yes {
+		style = style.Underline(true)
+	}
+	h.palette[tokenType] = style
+	return style
+}
+
+// highlight renders one line of code from path. Lines are highlighted in
+// isolation, so constructs spanning several lines (block comments, raw
+// strings) may be coloured as plain code. The fallback style is used when the
+// language is unknown and tint, if set, is applied as the line background.
+func (h *syntaxHighlighter) highlight(path, line string, fallback, tint lipgloss.Style) string {
+	lexer := h.lexerFor(path)
+	if lexer == nil {
+		return fallback.Inherit(tint).Render(line)
+	}
+
+	iterator, err := lexer.Tokenise(nil, line)
+	if err != nil {
+		return fallback.Inherit(tint).Render(line)
+	}
+
+	var sb strings.Builder
+	for token := iterator(); token != chroma.EOF; token = iterator() {
+		// Lexers append a trailing newline to their input
+		value := strings.TrimRight(token.Value, "\n")
+		if value == "" {
+			continue
+		}
+		sb.WriteString(h.tokenStyle(token.Type).Inherit(tint).Render(value))
+	}
+	return sb.String()
+}
+
+// styleDiffLine renders the text of a context (' '), removed ('-') or added ('+')
+// line, with syntax highlighting when a highlighter is configured
+func styleDiffLine(h *syntaxHighlighter, path string, kind byte, text string) string {
+	fallback, tint := styles.Neutral, lipgloss.NewStyle()
+	switch kind {
+	case '-':
+		fallback, tint = styles.Del, styles.DelLine
+	case '+':
+		fallback, tint = styles.Add, styles.AddLine
+	}
+
+	if h == nil {
+		return fallback.Render(text)
+	}
+	return h.highlight(path, text, fallback, tint)
+}
@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/huh"
+	"github.com/spf13/viper"
+
+	"github.com/aixoio/tt/styles"
+)
+
+// maxSubjectLength is the longest subject line the validator accepts without a warning
+const maxSubjectLength = 72
+
+// commitTypes are the conventional commit types the validator knows
+var commitTypes = []string{"feat", "fix", "docs", "style", "refactor", "perf", "test", "build", "ci", "chore", "revert"}
+
+// commitMessageTemplate appends the diffstat of what will be committed as
+// comment lines, the way git prepares COMMIT_EDITMSG
+func commitMessageTemplate(message string) string {
+	var sb strings.Builder
+	sb.WriteString(message)
+	sb.WriteString("\n\n# Please enter the commit message for your changes. Lines starting\n")
+	sb.WriteString("# with '#' will be ignored, and an empty message aborts the commit.\n")
+
+	// Callers stage before editing, so the index is exactly what will be committed
+	stat, _ := exec.Command("git", "diff", "--cached", "--stat", "--no-color").Output()
+	if len(stat) == 0 {
+		sb.WriteString("#\n# No changes added to commit.\n")
+	} else {
+		sb.WriteString("#\n# Changes to be committed:\n")
+		for _, line := range strings.Split(strings.TrimRight(string(stat), "\n"), "\n") {
+			sb.WriteString("#  " + line + "\n")
+		}
+	}
+	return sb.String()
+}
+
+// stripCommitComments drops comment lines and surrounding blank lines, like
+// git's default cleanup mode
+func stripCommitComments(text string) string {
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines = append(lines, strings.TrimRight(line, " \t"))
+	}
+	// Collapse runs of blank lines left behind by removed comments
+	lines = slices.CompactFunc(lines, func(a, b string) bool { return a == "" && b == "" })
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// editCommitMessage opens the message with its diffstat in the inline textarea
+// or, when message_editor is "editor", in $EDITOR
+func editCommitMessage(message string) (string, error) {
+	text := commitMessageTemplate(message)
+
+	if viper.GetString("message_editor") == "editor" {
+		dir := os.TempDir()
+		if gitDir, err := exec.Command("git", "rev-parse", "--git-dir").Output(); err == nil {
+			dir = strings.TrimSpace(string(gitDir))
+		}
+		path := filepath.Join(dir, "TT_EDITMSG")
+		if err := os.WriteFile(path, []byte(text), 0644); err != nil {
+			return "", fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		defer os.Remove(path)
+
+		if err := openEditor(path); err != nil {
+			return "", err
+		}
+		edited, err := os.ReadFile(path)
+		if err != nil {
+			return "", err
+		}
+		return stripCommitComments(string(edited)), nil
+	}
+
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewText().
+				Title(styles.Primary.Render("Commit Message")).
+				Description("Subject on the first line, then a blank line and the body. Lines starting with # are ignored.").
+				Lines(12).
+				CharLimit(0).
+				Value(&text),
+		),
+	).WithTheme(huh.ThemeCharm())
+	if err := form.Run(); err != nil {
+		return "", fmt.Errorf("failed to edit commit message: %w", err)
+	}
+	return stripCommitComments(text), nil
+}
+
+// commitMessageProblems checks a message against the usual subject rules and,
+// unless conventional_commits is off, the conventional commit format
+func commitMessageProblems(message string) []string {
+	if strings.TrimSpace(message) == "" {
+		return []string{"the message is empty"}
+	}
+
+	var problems []string
+	lines := strings.Split(message, "\n")
+	subject := lines[0]
+	if length := utf8.RuneCountInString(subject); length > maxSubjectLength {
+		problems = append(problems, fmt.Sprintf("the subject is %d characters long, keep it under %d", length, maxSubjectLength))
+	}
+	if len(lines) > 1 && strings.TrimSpace(lines[1]) != "" {
+		problems = append(problems, "leave a blank line between the subject and the body")
+	}
+
+	if viper.GetBool("conventional_commits") {
+		parsed, ok := parseConventionalCommit(subject, "")
+		switch {
+		case !ok:
+			problems = append(problems, "the subject does not follow type(scope): description")
+		case !slices.Contains(commitTypes, parsed.Type):
+			problems = append(problems, fmt.Sprintf("'%s' is not a conventional type (%s)", parsed.Type, strings.Join(commitTypes, ", ")))
+		}
+	}
+	return problems
+}
+
+// editAndValidate lets the user edit a message until it passes validation or
+// they accept it anyway. The second return value is false when they emptied
+// the message or discarded the edit, in which case the original should be kept.
+func editAndValidate(message string) (string, bool, error) {
+	edited := message
+	for {
+		var err error
+		edited, err = editCommitMessage(edited)
+		if err != nil {
+			return message, false, err
+		}
+
+		// An empty message aborts, as it does in git
+		if edited == "" {
+			return message, false, nil
+		}
+
+		problems := commitMessageProblems(edited)
+		if len(problems) == 0 {
+			return edited, true, nil
+		}
+
+		fmt.Println(styles.WarningIcon + " " + styles.Warning.Render("The commit message has problems:"))
+		for _, problem := range problems {
+			fmt.Println("  • " + styles.Neutral.Render(problem))
+		}
+
+		options := []huh.Option[string]{
+			huh.NewOption("✏️  Edit again", "edit"),
+			huh.NewOption("✅ Use it anyway", "accept"),
+			huh.NewOption("❌ Discard the edit", "discard"),
+		}
+
+		var choice string
+		selectForm := huh.NewForm(
+			huh.NewGroup(
+				huh.NewSelect[string]().
+					Title(styles.Primary.Render("What would you like to do?")).
+					Options(options...).
+					Value(&choice),
+			),
+		).WithTheme(huh.ThemeCharm())
+		if err := selectForm.Run(); err != nil {
+			return message, false, fmt.Errorf("error getting user selection: %w", err)
+		}
+
+		switch choice {
+		case "accept":
+			return edited, true, nil
+		case "discard":
+			return message, false, nil
+		}
+	}
+}
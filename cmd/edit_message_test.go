@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestStripCommitComments(t *testing.T) {
+	text := "feat(cmd): add edit option\n\nExplain the change.\n\n# Please enter the commit message\n#\n# Changes to be committed:\n#  cmd/aic.go | 4 ++--\n"
+	want := "feat(cmd): add edit option\n\nExplain the change."
+	if got := stripCommitComments(text); got != want {
+		t.Errorf("stripCommitComments() = %q, want %q", got, want)
+	}
+}
+
+func TestCommitMessageProblems(t *testing.T) {
+	viper.Set("conventional_commits", true)
+	t.Cleanup(func() { viper.Set("conventional_commits", nil) })
+
+	tests := []struct {
+		message string
+		want    []string
+	}{
+		{"feat(cmd): add edit option\n\nBody text.", nil},
+		{"", []string{"empty"}},
+		{"add edit option", []string{"type(scope)"}},
+		{"feature: add edit option", []string{"not a conventional type"}},
+		{"fix: handle it\nno blank line", []string{"blank line"}},
+		{"fix: " + strings.Repeat("x", 80), []string{"characters long"}},
+		{"fix: " + strings.Repeat("é", 60), nil},
+		{"fix: " + strings.Repeat("é", 70), []string{"75 characters long"}},
+	}
+	for _, tt := range tests {
+		problems := commitMessageProblems(tt.message)
+		if len(problems) != len(tt.want) {
+			t.Errorf("commitMessageProblems(%q) = %v, want %d problem(s)", tt.message, problems, len(tt.want))
+			continue
+		}
+		for i, want := range tt.want {
+			if !strings.Contains(problems[i], want) {
+				t.Errorf("commitMessageProblems(%q)[%d] = %q, want it to mention %q", tt.message, i, problems[i], want)
+			}
+		}
+	}
+}
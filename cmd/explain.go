@@ -0,0 +1,261 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/aixoio/tt/styles"
+)
+
+// explainTarget is a resolved commit, range or blamed line to explain
+type explainTarget struct {
+	// Commit is set for a single commit or a blamed line, From and To for a range
+	Commit string
+	From   string
+	To     string
+	// File and Line are set when explaining the commit that last touched a line
+	File string
+	Line int
+}
+
+// maxExplainDiffBytes keeps the prompt within model limits for large commits
+const maxExplainDiffBytes = 40000
+
+var explainCmd = &cobra.Command{
+	Use:   "explain <commit|range|file:line>",
+	Short: "Explain why a commit, range or line changed",
+	Long:  styles.Info.Render("Gather the commit message, diff and surrounding code for a commit, a range such as v1.0..v1.1, or the commit that last changed file:line, and ask the configured model for a plain-language explanation. Answers are cached, so asking again is free."),
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		modelFlag, _ := cmd.Flags().GetString("model")
+		noCache, _ := cmd.Flags().GetBool("no-cache")
+		raw, _ := cmd.Flags().GetBool("raw")
+
+		// Show header
+		fmt.Println(styles.Header.Render("Explain"))
+		fmt.Println()
+
+		if _, err := exec.Command("git", "rev-parse", "--git-dir").Output(); err != nil {
+			fmt.Println(styles.ErrorIcon + " " + styles.Error.Render("Not a git repository"))
+			return fmt.Errorf("not a git repository")
+		}
+
+		target, err := resolveExplainTarget(args[0])
+		if err != nil {
+			return err
+		}
+		fmt.Println(styles.InfoIcon + " " + styles.Info.Render("Explaining ") + target.label())
+
+		modelToUse := modelFlag
+		if modelToUse == "" {
+			modelToUse = viper.GetString("default_model")
+		}
+
+		// The template is part of the key so prompt changes are not served stale answers
+		promptText, _, err := loadPrompt("explain")
+		if err != nil {
+			return err
+		}
+		key := cacheKey(promptText, modelToUse, target.Commit, target.From, target.To, target.File, strconv.Itoa(target.Line))
+		explanation, cached := "", false
+		if !noCache {
+			explanation, cached = readCache("explain", key)
+		}
+
+		if cached {
+			fmt.Println(styles.InfoIcon + " " + styles.Muted.Render("Cached answer, use --no-cache to ask again"))
+		} else {
+			apiKey := viper.GetString("api_key")
+			if apiKey == "" {
+				fmt.Println(styles.ErrorIcon + " " + styles.Error.Render("API key not set. Run 'tt set' to configure it."))
+				return fmt.Errorf("API key not set")
+			}
+
+			prompt, err := buildExplainPrompt(target)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("%s %s: %s\n", styles.InfoIcon, styles.Info.Render("Using model"), styles.Highlight.Render(modelToUse))
+			explanation, err = runWithSpinnerForMessage("🤔 Working out what happened...", func() (string, error) {
+				// explain keeps its own cache keyed by commit, so the prompt cache is skipped
+				return aiComplete(AIRequest{APIKey: apiKey, Model: modelToUse, Prompt: prompt})
+			})
+			if err != nil {
+				fmt.Println(styles.ErrorIcon + " " + styles.Error.Render("Failed to generate explanation"))
+				return err
+			}
+
+			if err := writeCache("explain", key, explanation); err != nil {
+				fmt.Println(styles.WarningIcon + " " + styles.Warning.Render("Could not cache the answer: "+err.Error()))
+			}
+		}
+
+		fmt.Println()
+		if raw {
+			fmt.Println(explanation)
+		} else {
+			printMarkdown(explanation)
+		}
+		return nil
+	},
+}
+
+// resolveExplainTarget turns the argument into full commit hashes so cached
+// answers stay valid even when branch names move
+func resolveExplainTarget(arg string) (explainTarget, error) {
+	if from, to, found := strings.Cut(arg, ".."); found {
+		to = strings.TrimPrefix(to, ".")
+		if to == "" {
+			to = "HEAD"
+		}
+		fromHash, err := resolveCommit(from)
+		if err != nil {
+			return explainTarget{}, err
+		}
+		toHash, err := resolveCommit(to)
+		if err != nil {
+			return explainTarget{}, err
+		}
+		return explainTarget{From: fromHash, To: toHash}, nil
+	}
+
+	if path, lineText, found := strings.Cut(arg, ":"); found {
+		if line, err := strconv.Atoi(lineText); err == nil {
+			if _, statErr := os.Stat(path); statErr == nil {
+				return blameTarget(path, line)
+			}
+		}
+	}
+
+	commit, err := resolveCommit(arg)
+	if err != nil {
+		return explainTarget{}, err
+	}
+	return explainTarget{Commit: commit}, nil
+}
+
+func resolveCommit(ref string) (string, error) {
+	output, err := exec.Command("git", "rev-parse", "--verify", "--quiet", ref+"^{commit}").Output()
+	if err != nil {
+		return "", fmt.Errorf("unknown revision '%s'", ref)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// blameTarget finds the commit that last changed a line and where the line was in that commit
+func blameTarget(path string, line int) (explainTarget, error) {
+	output, err := exec.Command("git", "blame", "--porcelain", "-L", fmt.Sprintf("%d,%d", line, line), "--", path).Output()
+	if err != nil {
+		return explainTarget{}, fmt.Errorf("could not blame %s:%d", path, line)
+	}
+
+	// The first porcelain line is "<hash> <original line> <final line> <count>"
+	var origPath string
+	lines := strings.Split(string(output), "\n")
+	fields := strings.Fields(lines[0])
+	if len(fields) < 2 {
+		return explainTarget{}, fmt.Errorf("unexpected blame output for %s:%d", path, line)
+	}
+	for _, l := range lines[1:] {
+		if name, found := strings.CutPrefix(l, "filename "); found {
+			origPath = name
+			break
+		}
+	}
+
+	if strings.Trim(fields[0], "0") == "" {
+		return explainTarget{}, fmt.Errorf("%s:%d has not been committed yet", path, line)
+	}
+	origLine, _ := strconv.Atoi(fields[1])
+	if origPath == "" {
+		origPath = path
+	}
+	return explainTarget{Commit: fields[0], File: origPath, Line: origLine}, nil
+}
+
+func (t explainTarget) label() string {
+	switch {
+	case t.From != "":
+		return styles.CommitHash.Render(t.From[:7]) + ".." + styles.CommitHash.Render(t.To[:7])
+	case t.File != "":
+		return styles.FilePath.Render(fmt.Sprintf("%s:%d", t.File, t.Line)) + " from " + styles.CommitHash.Render(t.Commit[:7])
+	default:
+		return styles.CommitHash.Render(t.Commit[:7])
+	}
+}
+
+// buildExplainPrompt collects messages, diffs with extra context and, for a line, the code around it
+func buildExplainPrompt(t explainTarget) (string, error) {
+	data := newPromptData("", nil)
+
+	if t.From != "" {
+		logOutput, err := exec.Command("git", "log", "--format=- %h %s (%an, %ad)%n%b", "--date=short", t.From+".."+t.To).Output()
+		if err != nil {
+			return "", fmt.Errorf("failed to get commits: %w", err)
+		}
+		data.Commits = string(logOutput)
+		diff, err := exec.Command("git", "diff", "--no-color", "-U8", t.From, t.To).Output()
+		if err != nil {
+			return "", fmt.Errorf("failed to get diff: %w", err)
+		}
+		data.Diff = truncateForPrompt(string(diff), maxExplainDiffBytes, "truncated")
+		return renderPrompt("explain", data)
+	}
+
+	message, err := exec.Command("git", "show", "--no-patch", "--format=Commit %H%nAuthor: %an, %ad%n%n%B", "--date=short", t.Commit).Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get commit message: %w", err)
+	}
+	data.Message = string(message)
+
+	if t.File != "" {
+		data.File, data.Line = t.File, t.Line
+		if content, err := exec.Command("git", "show", t.Commit+":"+t.File).Output(); err == nil {
+			var context strings.Builder
+			fileLines := strings.Split(strings.TrimRight(string(content), "\n"), "\n")
+			start, end := max(t.Line-20, 1), min(t.Line+20, len(fileLines))
+			for i := start; i <= end; i++ {
+				marker := "  "
+				if i == t.Line {
+					marker = "> "
+				}
+				context.WriteString(fmt.Sprintf("%s%5d %s\n", marker, i, fileLines[i-1]))
+			}
+			data.FileContext = context.String()
+		}
+	}
+
+	diff, err := exec.Command("git", "show", "--format=", "--no-color", "-U8", t.Commit).Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get diff: %w", err)
+	}
+	data.Diff = truncateForPrompt(string(diff), maxExplainDiffBytes, "truncated")
+	return renderPrompt("explain", data)
+}
+
+// truncateForPrompt cuts text to at most limit bytes and appends note, backing
+// up to a rune boundary so the prompt stays valid UTF-8
+func truncateForPrompt(text string, limit int, note string) string {
+	if len(text) <= limit {
+		return text
+	}
+	for limit > 0 && !utf8.RuneStart(text[limit]) {
+		limit--
+	}
+	return text[:limit] + "\n... (" + note + ")\n"
+}
+
+func init() {
+	rootCmd.AddCommand(explainCmd)
+	explainCmd.Flags().StringP("model", "m", "", "Model to use (overrides default_model from config)")
+	explainCmd.Flags().Bool("no-cache", false, "Ask the model again instead of using a cached answer")
+	explainCmd.Flags().Bool("raw", false, "Print raw markdown instead of rendering it")
+}
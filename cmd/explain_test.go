@@ -0,0 +1,22 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestTruncateForPrompt(t *testing.T) {
+	if got := truncateForPrompt("short", 10, "truncated"); got != "short" {
+		t.Errorf("truncateForPrompt() = %q, want the text unchanged", got)
+	}
+
+	// "é" is two bytes, so a limit of 3 falls inside the second one
+	got := truncateForPrompt("ééé", 3, "truncated")
+	if !utf8.ValidString(got) {
+		t.Errorf("truncateForPrompt() = %q, not valid UTF-8", got)
+	}
+	if !strings.HasPrefix(got, "é\n") {
+		t.Errorf("truncateForPrompt() = %q, want it cut back to one rune", got)
+	}
+}
@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/charmbracelet/huh"
+	"github.com/spf13/cobra"
+
+	"github.com/aixoio/tt/styles"
+)
+
+// fixupTargets lists recent commits that touched any of the files, newest
+// first, skipping earlier fixup! and squash! commits
+func fixupTargets(files []string, limit int) ([]CommitInfo, error) {
+	args := append([]string{"log", "--no-merges", "-n", fmt.Sprint(limit), "--format=%h%x09%s%x09%an%x09%ad", "--date=short", "--"}, files...)
+	output, err := exec.Command("git", args...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recent commits: %w", err)
+	}
+
+	var commits []CommitInfo
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		fields := strings.SplitN(line, "\t", 4)
+		if len(fields) != 4 || strings.HasPrefix(fields[1], "fixup! ") || strings.HasPrefix(fields[1], "squash! ") {
+			continue
+		}
+		commits = append(commits, CommitInfo{Hash: fields[0], Message: fields[1], Author: fields[2], Date: fields[3]})
+	}
+	return commits, nil
+}
+
+// selectFixupTarget shows the commits that touched the staged files, falling
+// back to the most recent commits when none did
+func selectFixupTarget(files []string) (string, error) {
+	commits, err := fixupTargets(files, 15)
+	if err != nil {
+		return "", err
+	}
+	if len(commits) == 0 {
+		fmt.Println(styles.InfoIcon + " " + styles.Info.Render("No recent commits touch the staged files, showing the latest commits"))
+		if commits, err = fixupTargets(nil, 15); err != nil {
+			return "", err
+		}
+	}
+	if len(commits) == 0 {
+		return "", fmt.Errorf("no commits found")
+	}
+
+	var options []huh.Option[string]
+	for _, commit := range commits {
+		display := fmt.Sprintf("%s %s %s", styles.CommitHash.Render(commit.Hash), styles.Primary.Render(commit.Message), styles.Muted.Render(commit.Author+", "+commit.Date))
+		if isPushed(commit.Hash) {
+			display += " " + styles.Warning.Render("(pushed)")
+		}
+		options = append(options, huh.NewOption(display, commit.Hash))
+	}
+
+	var selectedHash string
+	selectPrompt := huh.NewSelect[string]().
+		Title(styles.Primary.Render("Select the commit to fix up:")).
+		Options(options...).
+		Value(&selectedHash).
+		WithTheme(huh.ThemeCharm())
+
+	if err := selectPrompt.Run(); err != nil {
+		return "", fmt.Errorf("failed to select commit: %w", err)
+	}
+	return selectedHash, nil
+}
+
+// autosquash folds fixup! commits into their targets without opening the todo list
+func autosquash(target string) error {
+	base := []string{"rebase", "-i", "--autosquash", "--autostash"}
+	if parent := parentOf(target); parent == emptyTree {
+		base = append(base, "--root")
+	} else {
+		base = append(base, parent)
+	}
+
+	rebaseCmd := exec.Command("git", base...)
+	rebaseCmd.Env = append(os.Environ(), "GIT_SEQUENCE_EDITOR=:")
+	rebaseCmd.Stdout = os.Stdout
+	rebaseCmd.Stderr = os.Stderr
+	if err := rebaseCmd.Run(); err != nil {
+		fmt.Println(styles.ErrorIcon + " " + styles.Error.Render("Rebase stopped. Resolve the conflicts and run 'git rebase --continue', or 'git rebase --abort' to undo it."))
+		return fmt.Errorf("autosquash rebase failed: %w", err)
+	}
+	return nil
+}
+
+var fixupCmd = &cobra.Command{
+	Use:   "fixup [commit]",
+	Short: "Commit staged changes as a fixup of an earlier commit",
+	Long:  styles.Info.Render("Create a fixup! commit for an earlier commit, picked from the recent commits that touch the staged files. With --squash, or after confirming, an autosquash rebase folds it in right away."),
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		add, _ := cmd.Flags().GetBool("add")
+		squash, _ := cmd.Flags().GetBool("squash")
+		allowSecrets, _ := cmd.Flags().GetBool("allow-secrets")
+
+		fmt.Println(styles.Header.Render("Fixup Commit"))
+		fmt.Println()
+
+		if add {
+			fmt.Print(styles.InfoIcon + " " + styles.Info.Render("Staging all files... "))
+			if err := exec.Command("git", "add", ".").Run(); err != nil {
+				fmt.Println(styles.ErrorIcon)
+				return fmt.Errorf("failed to add files: %w", err)
+			}
+			fmt.Println(styles.SuccessIcon)
+		}
+
+		output, err := exec.Command("git", "diff", "--cached", "--name-only").Output()
+		if err != nil {
+			return fmt.Errorf("failed to get staged files: %w", err)
+		}
+		files := strings.Fields(string(output))
+		if len(files) == 0 {
+			fmt.Println(styles.InfoIcon + " " + styles.Info.Render("Nothing staged. Stage the fix first, or pass -a to stage everything."))
+			return nil
+		}
+
+		var target string
+		if len(args) > 0 {
+			resolved, err := exec.Command("git", "rev-parse", "--verify", "--quiet", args[0]+"^{commit}").Output()
+			if err != nil {
+				fmt.Println(styles.ErrorIcon + " " + styles.Error.Render("Unknown commit: "+args[0]))
+				return fmt.Errorf("unknown commit %s", args[0])
+			}
+			target = strings.TrimSpace(string(resolved))
+			// The autosquash rebase starts from the target, so it must be in HEAD's history
+			if err := exec.Command("git", "merge-base", "--is-ancestor", target, "HEAD").Run(); err != nil {
+				fmt.Println(styles.ErrorIcon + " " + styles.Error.Render(args[0]+" is not in the history of the current branch"))
+				return fmt.Errorf("%s is not an ancestor of HEAD", args[0])
+			}
+		} else {
+			if target, err = selectFixupTarget(files); err != nil {
+				return err
+			}
+		}
+
+		if err := checkStagedSecrets(allowSecrets); err != nil {
+			return err
+		}
+
+		gitCmd := exec.Command("git", withSigning(shouldSign(cmd, "sign_commits"), "-S", "commit", "--fixup="+target)...)
+		gitCmd.Stdout = os.Stdout
+		gitCmd.Stderr = os.Stderr
+		if err := gitCmd.Run(); err != nil {
+			return fmt.Errorf("failed to create fixup commit: %w", err)
+		}
+
+		details, err := getCommitDetails(target)
+		if err != nil {
+			return err
+		}
+		fmt.Println(styles.Card.Render(
+			styles.Success.Render("Fixup commit created for:") + "\n" +
+				styles.CommitHash.Render(details.Hash[:7]) + " " + styles.Primary.Render(details.Message),
+		))
+
+		if !cmd.Flags().Changed("squash") {
+			confirm := huh.NewConfirm().
+				Title(styles.Primary.Render("Squash it in now?")).
+				Description("Runs an autosquash rebase from " + details.Hash[:7] + ", rewriting the commits after it.").
+				Affirmative("Squash").
+				Negative("Later").
+				Value(&squash).
+				WithTheme(huh.ThemeCharm())
+			if err := confirm.Run(); err != nil {
+				return fmt.Errorf("failed to show confirmation prompt: %w", err)
+			}
+		}
+		if !squash {
+			fmt.Println(styles.InfoIcon + " " + styles.Info.Render("Squash it later with ") + styles.Highlight.Render("git rebase -i --autosquash "+details.Hash[:7]+"~1"))
+			return nil
+		}
+
+		if isPushed(target) {
+			fmt.Println(styles.WarningIcon + " " + styles.Warning.Render("The commit is already pushed; you will need to force-push after squashing"))
+		}
+		if err := autosquash(target); err != nil {
+			return err
+		}
+		fmt.Println(styles.SuccessIcon + " " + styles.Success.Render("Fixup squashed into ") + styles.Primary.Render(details.Message))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(fixupCmd)
+	fixupCmd.Flags().BoolP("add", "a", false, "Add all files before committing")
+	fixupCmd.Flags().Bool("squash", false, "Run an autosquash rebase right away (--squash=false to skip the prompt)")
+	fixupCmd.Flags().Bool("allow-secrets", false, "Commit even if the secret scanner finds something")
+	fixupCmd.Flags().BoolP("sign", "S", false, "Sign the commit with GPG or SSH (overrides sign_commits from config)")
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -18,6 +19,8 @@ var keyGetCmd = &cobra.Command{
 		baseURL := viper.GetString("base_url")
 		defaultModel := viper.GetString("default_model")
 		diffModel := viper.GetString("diff_model")
+		signingFormat := viper.GetString("signing_format")
+		signingKey := viper.GetString("signing_key")
 
 		fmt.Println(styles.Header.Render("Current Configuration"))
 		fmt.Println()
@@ -31,6 +34,30 @@ var keyGetCmd = &cobra.Command{
 		fmt.Println(styles.InfoIcon + " " + styles.Info.Render("Base URL: ") + styles.Highlight.Render(baseURL))
 		fmt.Println(styles.InfoIcon + " " + styles.Info.Render("Default Model: ") + styles.Highlight.Render(defaultModel))
 		fmt.Println(styles.InfoIcon + " " + styles.Info.Render("Diff Model: ") + styles.Highlight.Render(diffModel))
+		fmt.Println(styles.InfoIcon + " " + styles.Info.Render("Signing Format: ") + styles.Highlight.Render(signingFormat))
+		fmt.Println(styles.InfoIcon + " " + styles.Info.Render("Signing Key: ") + styles.Highlight.Render(signingKey))
+		fmt.Println(styles.InfoIcon + " " + styles.Info.Render("Sign Commits: ") + styles.Highlight.Render(fmt.Sprint(viper.GetBool("sign_commits"))))
+		fmt.Println(styles.InfoIcon + " " + styles.Info.Render("Sign Tags: ") + styles.Highlight.Render(fmt.Sprint(viper.GetBool("sign_tags"))))
+		fmt.Println(styles.InfoIcon + " " + styles.Info.Render("Diff Theme: ") + styles.Highlight.Render(viper.GetString("diff_theme")))
+		fmt.Println(styles.InfoIcon + " " + styles.Info.Render("Pager: ") + styles.Highlight.Render(viper.GetString("pager")))
+		for _, forgeName := range []string{"GitHub", "GitLab", "Gitea"} {
+			status := "Not set"
+			if viper.GetString(strings.ToLower(forgeName)+"_token") != "" {
+				status = "Set"
+			}
+			fmt.Println(styles.InfoIcon + " " + styles.Info.Render(forgeName+" Token: ") + styles.Highlight.Render(status))
+		}
+		if forgeType := viper.GetString("forge_type"); forgeType != "" {
+			fmt.Println(styles.InfoIcon + " " + styles.Info.Render("Forge Type: ") + styles.Highlight.Render(forgeType))
+		}
+		if forgeAPIURL := viper.GetString("forge_api_url"); forgeAPIURL != "" {
+			fmt.Println(styles.InfoIcon + " " + styles.Info.Render("Forge API URL: ") + styles.Highlight.Render(forgeAPIURL))
+		}
+		fmt.Println(styles.InfoIcon + " " + styles.Info.Render("Message Editor: ") + styles.Highlight.Render(viper.GetString("message_editor")))
+		fmt.Println(styles.InfoIcon + " " + styles.Info.Render("AI Cache: ") + styles.Highlight.Render(fmt.Sprint(viper.GetBool("ai_cache"))))
+		if budget := viper.GetFloat64("monthly_budget"); budget > 0 {
+			fmt.Println(styles.InfoIcon + " " + styles.Info.Render("Monthly Budget: ") + styles.Highlight.Render(fmt.Sprintf("$%.2f (%s)", budget, viper.GetString("budget_action"))))
+		}
 
 		return nil
 	},
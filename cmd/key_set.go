@@ -2,7 +2,11 @@ package cmd
 
 import (
 	"fmt"
+	"slices"
+	"strconv"
+	"strings"
 
+	chromastyles "github.com/alecthomas/chroma/v2/styles"
 	"github.com/charmbracelet/huh"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -28,6 +32,21 @@ var keySetCmd = &cobra.Command{
 						huh.NewOption("Base URL", "base_url"),
 						huh.NewOption("Default Model", "default_model"),
 						huh.NewOption("Diff Model", "diff_model"),
+						huh.NewOption("Signing Format", "signing_format"),
+						huh.NewOption("Signing Key", "signing_key"),
+						huh.NewOption("Sign Commits", "sign_commits"),
+						huh.NewOption("Sign Tags", "sign_tags"),
+						huh.NewOption("Diff Theme", "diff_theme"),
+						huh.NewOption("Pager", "pager"),
+						huh.NewOption("GitHub Token", "github_token"),
+						huh.NewOption("GitLab Token", "gitlab_token"),
+						huh.NewOption("Gitea Token", "gitea_token"),
+						huh.NewOption("Forge Type", "forge_type"),
+						huh.NewOption("Forge API URL", "forge_api_url"),
+						huh.NewOption("AI Cache", "ai_cache"),
+						huh.NewOption("Monthly Budget", "monthly_budget"),
+						huh.NewOption("Budget Action", "budget_action"),
+						huh.NewOption("Message Editor", "message_editor"),
 					).
 					Value(&configOption),
 			),
@@ -89,6 +108,150 @@ var keySetCmd = &cobra.Command{
 					}
 					return nil
 				})
+		case "signing_format":
+			input = huh.NewInput().
+				Title(styles.Primary.Render("Signing Format")).
+				Placeholder("openpgp").
+				Description("Signature format passed to git as gpg.format (openpgp, ssh or x509)").
+				Value(&value).
+				Validate(func(s string) error {
+					if s != "openpgp" && s != "ssh" && s != "x509" {
+						return fmt.Errorf("signing format must be openpgp, ssh or x509")
+					}
+					return nil
+				})
+		case "signing_key":
+			input = huh.NewInput().
+				Title(styles.Primary.Render("Signing Key")).
+				Placeholder("~/.ssh/id_ed25519.pub").
+				Description("GPG key ID or SSH public key path passed to git as user.signingkey").
+				Value(&value).
+				Validate(func(s string) error {
+					if s == "" {
+						return fmt.Errorf("signing key cannot be empty")
+					}
+					return nil
+				})
+		case "sign_commits", "sign_tags":
+			input = huh.NewInput().
+				Title(styles.Primary.Render("Sign by Default")).
+				Placeholder("true").
+				Description("Enter true to sign by default or false to only sign with --sign").
+				Value(&value).
+				Validate(func(s string) error {
+					if s != "true" && s != "false" {
+						return fmt.Errorf("value must be true or false")
+					}
+					return nil
+				})
+		case "diff_theme":
+			input = huh.NewInput().
+				Title(styles.Primary.Render("Diff Theme")).
+				Placeholder(defaultDiffTheme).
+				Description("Chroma style for syntax-highlighted diffs (e.g. monokai, dracula, github), or none to disable").
+				Value(&value).
+				Validate(func(s string) error {
+					if s != "none" && !slices.Contains(chromastyles.Names(), s) {
+						return fmt.Errorf("unknown theme, choose one of: %s", strings.Join(chromastyles.Names(), ", "))
+					}
+					return nil
+				})
+		case "pager":
+			input = huh.NewInput().
+				Title(styles.Primary.Render("Pager")).
+				Placeholder("internal").
+				Description("How long diff and log output is paged (internal, external or never)").
+				Value(&value).
+				Validate(func(s string) error {
+					if s != "internal" && s != "external" && s != "never" {
+						return fmt.Errorf("pager must be internal, external or never")
+					}
+					return nil
+				})
+		case "github_token", "gitlab_token", "gitea_token":
+			input = huh.NewInput().
+				Title(styles.Primary.Render("Forge Token")).
+				Description("Personal access token used to open pull requests").
+				Value(&value).
+				EchoMode(huh.EchoModePassword).
+				Validate(func(s string) error {
+					if s == "" {
+						return fmt.Errorf("token cannot be empty")
+					}
+					return nil
+				})
+		case "forge_type":
+			input = huh.NewInput().
+				Title(styles.Primary.Render("Forge Type")).
+				Placeholder("github").
+				Description("Forge hosting origin when it cannot be detected from the host (github, gitlab or gitea)").
+				Value(&value).
+				Validate(func(s string) error {
+					if s != "github" && s != "gitlab" && s != "gitea" {
+						return fmt.Errorf("forge type must be github, gitlab or gitea")
+					}
+					return nil
+				})
+		case "forge_api_url":
+			input = huh.NewInput().
+				Title(styles.Primary.Render("Forge API URL")).
+				Placeholder("https://git.example.com/api/v1").
+				Description("API root for a self-hosted forge on a non-standard path").
+				Value(&value).
+				Validate(func(s string) error {
+					if !strings.HasPrefix(s, "http://") && !strings.HasPrefix(s, "https://") {
+						return fmt.Errorf("API URL must start with http:// or https://")
+					}
+					return nil
+				})
+		case "ai_cache":
+			input = huh.NewInput().
+				Title(styles.Primary.Render("AI Cache")).
+				Placeholder("true").
+				Description("Enter true to reuse answers for identical prompts or false to always ask the model").
+				Value(&value).
+				Validate(func(s string) error {
+					if s != "true" && s != "false" {
+						return fmt.Errorf("value must be true or false")
+					}
+					return nil
+				})
+		case "monthly_budget":
+			input = huh.NewInput().
+				Title(styles.Primary.Render("Monthly Budget")).
+				Placeholder("5.00").
+				Description("AI spend limit in dollars per calendar month, or 0 for no limit").
+				Value(&value).
+				Validate(func(s string) error {
+					if budget, err := strconv.ParseFloat(s, 64); err != nil || budget < 0 {
+						return fmt.Errorf("budget must be a non-negative number")
+					}
+					return nil
+				})
+		case "budget_action":
+			input = huh.NewInput().
+				Title(styles.Primary.Render("Budget Action")).
+				Placeholder("warn").
+				Description("What happens once the monthly budget is spent (warn or block)").
+				Value(&value).
+				Validate(func(s string) error {
+					if s != "warn" && s != "block" {
+						return fmt.Errorf("budget action must be warn or block")
+					}
+					return nil
+				})
+		case "message_editor":
+			input = huh.NewInput().
+				Title(styles.Primary.Render("Message Editor")).
+				Placeholder("inline").
+				Description("Where commit messages are edited (inline textarea or $EDITOR)").
+				Value(&value).
+				Validate(func(s string) error {
+					if s != "inline" && s != "editor" {
+						return fmt.Errorf("message editor must be inline or editor")
+					}
+					return nil
+				})
 		}
 
 		form := huh.NewForm(
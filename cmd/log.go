@@ -1,28 +1,67 @@
 package cmd
 
 import (
-	"bufio"
+	"errors"
 	"fmt"
 	"os/exec"
-	"regexp"
 	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
 	"github.com/aixoio/tt/styles"
 )
 
+// LogEntry is a single line of structured git log output. Graph-only lines
+// (when --graph is used) have an empty Hash.
+type LogEntry struct {
+	Graph     string
+	Hash      string
+	ShortHash string
+	Refs      string
+	Author    string
+	Date      string
+	Signature string
+	Subject   string
+}
+
+// LogOptions holds the filters and display options for git log
+type LogOptions struct {
+	Count     int
+	All       bool
+	Graph     bool
+	Reverse   bool
+	Merges    bool
+	NoMerges  bool
+	Author    string
+	Since     string
+	Until     string
+	Grep      string
+	Revisions []string
+	Paths     []string
+}
+
+// logFormat starts each commit with a unit separator so the graph prefix can be
+// split off, and separates fields with NUL bytes so no subject can break parsing.
+const logFormat = "--format=%x1f%H%x00%h%x00%D%x00%an%x00%ar%x00%G?%x00%s"
+
 // logCmd represents the log command
 var logCmd = &cobra.Command{
-	Use:     "log [options]",
+	Use:     "log [range] [-- <path>...]",
 	Aliases: []string{"l"},
 	Short:   "Show commit history with beautiful formatting",
-	Long:    "Display git commit history in a styled, readable format similar to git log --oneline",
+	Long:    "Display git commit history in a styled, readable format with author, relative date and signature status. Supports filtering by author, date, message, path and range.",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		full, _ := cmd.Flags().GetBool("full")
-		count, _ := cmd.Flags().GetInt("count")
-		all, _ := cmd.Flags().GetBool("all")
-		graph, _ := cmd.Flags().GetBool("graph")
+		opts := logOptionsFromFlags(cmd, args)
+
+		if interactive, _ := cmd.Flags().GetBool("interactive"); interactive {
+			// The browser always shows the graph column and loads a larger window of history
+			opts.Graph, opts.Reverse = true, false
+			if full, _ := cmd.Flags().GetBool("full"); !full && !cmd.Flags().Changed("count") {
+				opts.Count = 200
+			}
+			return runLogBrowser(opts)
+		}
 
 		// Show header
 		fmt.Println(styles.Header.Render("Git Log"))
@@ -36,131 +75,48 @@ var logCmd = &cobra.Command{
 
 		// Get current branch
 		branchCmd := exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD")
-		branchOutput, err := branchCmd.Output()
-		if err != nil {
-			return fmt.Errorf("failed to get current branch: %w", err)
+		if branchOutput, err := branchCmd.Output(); err == nil {
+			currentBranch := strings.TrimSpace(string(branchOutput))
+			fmt.Println(styles.Primary.Render("On branch: ") + styles.Branch.Render(currentBranch))
+			fmt.Println()
 		}
-		currentBranch := strings.TrimSpace(string(branchOutput))
-		fmt.Println(styles.Primary.Render("On branch: ") + styles.Branch.Render(currentBranch))
-		fmt.Println()
-
-		// Build git log command
-		gitArgs := []string{"log", "--oneline", "--decorate"}
-
-		// Add reverse flag to show in reverse chronological order
-		gitArgs = append(gitArgs, "--reverse")
 
-		// Set default count to 10 if not full
-		if !full && count == 10 {
-			gitArgs = append(gitArgs, "-n10")
-		} else if !full && count > 0 {
-			gitArgs = append(gitArgs, fmt.Sprintf("-n%d", count))
+		if opts.Graph && opts.Reverse {
+			fmt.Println(styles.WarningIcon + " " + styles.Warning.Render("--reverse cannot be combined with --graph, showing newest first"))
+			fmt.Println()
+			opts.Reverse = false
 		}
 
-		if all {
-			gitArgs = append(gitArgs, "--all")
-		}
-
-		if graph {
-			gitArgs = append(gitArgs, "--graph")
-		}
-
-		// Execute git log command
-		gitCmd := exec.Command("git", gitArgs...)
-		stdout, err := gitCmd.StdoutPipe()
+		entries, err := getLogEntries(opts)
 		if err != nil {
-			return fmt.Errorf("failed to create stdout pipe: %w", err)
-		}
-
-		if err := gitCmd.Start(); err != nil {
-			return fmt.Errorf("failed to start git log: %w", err)
+			return err
 		}
 
-		// Process output line by line
-		scanner := bufio.NewScanner(stdout)
-		hasOutput := false
-		for scanner.Scan() {
-			hasOutput = true
-			line := scanner.Text()
-
-			if graph {
-				// For graph mode, parse and style components
-				re := regexp.MustCompile(`([0-9a-f]{7,40})`)
-				loc := re.FindStringIndex(line)
-				if loc != nil {
-					graphPart := line[:loc[0]]
-					rest := line[loc[1]:]
-					hash := line[loc[0]:loc[1]]
-					// Parse rest for decorations and message
-					fields := strings.Fields(rest)
-					if len(fields) > 0 && strings.HasPrefix(fields[0], "(") {
-						decorationEnd := strings.Index(rest, ") ")
-						if decorationEnd != -1 {
-							decorations := rest[:decorationEnd+1]
-							message := rest[decorationEnd+2:]
-							styledLine := styles.Muted.Render(graphPart) + styles.CommitHash.Render(hash) + " " + styles.Branch.Render(decorations) + " " + styles.Primary.Render(message)
-							fmt.Println(styledLine)
-						} else {
-							// Malformed, fallback
-							styledLine := styles.Muted.Render(graphPart) + styles.CommitHash.Render(hash) + " " + styles.Primary.Render(rest)
-							fmt.Println(styledLine)
-						}
-					} else {
-						message := rest
-						styledLine := styles.Muted.Render(graphPart) + styles.CommitHash.Render(hash) + " " + styles.Primary.Render(message)
-						fmt.Println(styledLine)
-					}
-				} else {
-					// No hash found, treat as graph-only line
-					fmt.Println(styles.Neutral.Render(line))
-				}
-			} else {
-				// Parse commit hash, decorations, and message
-				fields := strings.Fields(line)
-				if len(fields) >= 2 {
-					hash := fields[0]
-					if strings.HasPrefix(fields[1], "(") {
-						// Find the closing )
-						decorationEnd := strings.Index(line, ") ")
-						if decorationEnd != -1 {
-							decorations := line[len(hash)+1 : decorationEnd+1]
-							message := line[decorationEnd+2:]
-							styledLine := styles.CommitHash.Render(hash) + " " + styles.Branch.Render(decorations) + " " + styles.Primary.Render(message)
-							fmt.Println(styledLine)
-						} else {
-							// Malformed, fallback
-							styledLine := styles.CommitHash.Render(hash) + " " + styles.Primary.Render(strings.Join(fields[1:], " "))
-							fmt.Println(styledLine)
-						}
-					} else {
-						message := strings.Join(fields[1:], " ")
-						styledLine := styles.CommitHash.Render(hash) + " " + styles.Primary.Render(message)
-						fmt.Println(styledLine)
-					}
-				} else {
-					// Fallback for any other format
-					fmt.Println(styles.Neutral.Render(line))
-				}
+		var lines []string
+		hasCommits := false
+		for _, entry := range entries {
+			if entry.Hash == "" {
+				lines = append(lines, styles.Muted.Render(entry.Graph))
+				continue
 			}
+			hasCommits = true
+			lines = append(lines, formatLogEntry(entry))
 		}
 
 		// Show message if no commits
-		if !hasOutput {
+		if !hasCommits {
 			fmt.Println(styles.InfoIcon + " " + styles.Info.Render("No commits found"))
-		}
-
-		if err := gitCmd.Wait(); err != nil {
-			// Git log might exit with error if there are no commits
-			return nil
-		}
-
-		if err := scanner.Err(); err != nil {
-			return fmt.Errorf("error reading git log output: %w", err)
+		} else {
+			noPager, _ := cmd.Flags().GetBool("no-pager")
+			if err := showOutput("Git Log", strings.Join(lines, "\n"), !noPager); err != nil {
+				return err
+			}
 		}
 
 		// Check for uncommitted changes
 		statusCmd := exec.Command("git", "status", "--porcelain")
 		if statusOutput, err := statusCmd.Output(); err == nil && len(strings.TrimSpace(string(statusOutput))) > 0 {
+			fmt.Println()
 			fmt.Println(styles.WarningIcon + " " + styles.Warning.Render("Uncommitted changes"))
 		}
 
@@ -168,10 +124,158 @@ var logCmd = &cobra.Command{
 	},
 }
 
+// logOptionsFromFlags reads the log flags and splits args into revisions and paths
+func logOptionsFromFlags(cmd *cobra.Command, args []string) LogOptions {
+	full, _ := cmd.Flags().GetBool("full")
+	count, _ := cmd.Flags().GetInt("count")
+
+	opts := LogOptions{Count: count, Reverse: viper.GetBool("log_reverse")}
+	if full {
+		opts.Count = 0
+	}
+	opts.All, _ = cmd.Flags().GetBool("all")
+	opts.Graph, _ = cmd.Flags().GetBool("graph")
+	opts.Merges, _ = cmd.Flags().GetBool("merges")
+	opts.NoMerges, _ = cmd.Flags().GetBool("no-merges")
+	opts.Author, _ = cmd.Flags().GetString("author")
+	opts.Since, _ = cmd.Flags().GetString("since")
+	opts.Until, _ = cmd.Flags().GetString("until")
+	opts.Grep, _ = cmd.Flags().GetString("grep")
+	if cmd.Flags().Changed("reverse") {
+		opts.Reverse, _ = cmd.Flags().GetBool("reverse")
+	}
+
+	if dash := cmd.ArgsLenAtDash(); dash >= 0 {
+		opts.Revisions = args[:dash]
+		opts.Paths = args[dash:]
+	} else {
+		opts.Revisions = args
+	}
+
+	return opts
+}
+
+// buildLogArgs turns log options into git log arguments
+func buildLogArgs(opts LogOptions) []string {
+	args := []string{"log", logFormat}
+
+	if opts.Count > 0 {
+		args = append(args, fmt.Sprintf("-n%d", opts.Count))
+	}
+	if opts.All {
+		args = append(args, "--all")
+	}
+	if opts.Graph {
+		args = append(args, "--graph")
+	}
+	if opts.Reverse {
+		args = append(args, "--reverse")
+	}
+	if opts.Merges {
+		args = append(args, "--merges")
+	}
+	if opts.NoMerges {
+		args = append(args, "--no-merges")
+	}
+	if opts.Author != "" {
+		args = append(args, "--author="+opts.Author)
+	}
+	if opts.Since != "" {
+		args = append(args, "--since="+opts.Since)
+	}
+	if opts.Until != "" {
+		args = append(args, "--until="+opts.Until)
+	}
+	if opts.Grep != "" {
+		args = append(args, "--grep="+opts.Grep, "--regexp-ignore-case")
+	}
+
+	args = append(args, opts.Revisions...)
+	if len(opts.Paths) > 0 {
+		args = append(args, "--")
+		args = append(args, opts.Paths...)
+	}
+
+	return args
+}
+
+// getLogEntries runs git log with the structured format and parses every line
+func getLogEntries(opts LogOptions) ([]LogEntry, error) {
+	output, err := exec.Command("git", buildLogArgs(opts)...).Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			stderr := strings.TrimSpace(string(exitErr.Stderr))
+			// A repository without commits is not an error worth reporting
+			if strings.Contains(stderr, "does not have any commits") {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("git log failed: %s", stderr)
+		}
+		return nil, fmt.Errorf("git log failed: %w", err)
+	}
+
+	var entries []LogEntry
+	for line := range strings.SplitSeq(strings.TrimRight(string(output), "\n"), "\n") {
+		if line == "" {
+			continue
+		}
+		entries = append(entries, parseLogLine(line))
+	}
+
+	return entries, nil
+}
+
+// parseLogLine parses one line produced by logFormat, keeping any graph prefix
+func parseLogLine(line string) LogEntry {
+	graph, record, found := strings.Cut(line, "\x1f")
+	if !found {
+		return LogEntry{Graph: line}
+	}
+
+	fields := strings.Split(record, "\x00")
+	if len(fields) < 7 {
+		return LogEntry{Graph: line}
+	}
+
+	return LogEntry{
+		Graph:     graph,
+		Hash:      fields[0],
+		ShortHash: fields[1],
+		Refs:      fields[2],
+		Author:    fields[3],
+		Date:      fields[4],
+		Signature: fields[5],
+		Subject:   fields[6],
+	}
+}
+
+// formatLogEntry renders a commit line with hash, refs, subject, author, date and signature
+func formatLogEntry(entry LogEntry) string {
+	line := styles.Muted.Render(entry.Graph) + styles.CommitHash.Render(entry.ShortHash) + " "
+	if entry.Refs != "" {
+		line += styles.Branch.Render("("+entry.Refs+")") + " "
+	}
+	line += styles.Primary.Render(entry.Subject) + " " +
+		styles.Muted.Render("— "+entry.Author+", "+entry.Date) + " " +
+		signatureBadge(entry.Signature)
+	return line
+}
+
 func init() {
 	rootCmd.AddCommand(logCmd)
 	logCmd.Flags().BoolP("full", "f", false, "Show all commits (default: show last 10)")
 	logCmd.Flags().IntP("count", "n", 10, "Number of commits to show (ignored with --full)")
 	logCmd.Flags().BoolP("all", "a", false, "Show all branches")
 	logCmd.Flags().BoolP("graph", "g", false, "Show graph")
+	logCmd.Flags().BoolP("reverse", "r", false, "Show oldest commits first (overrides log_reverse from config)")
+	logCmd.Flags().String("author", "", "Only show commits by a matching author")
+	logCmd.Flags().String("since", "", "Only show commits after a date (e.g. \"2 weeks ago\", 2024-01-31)")
+	logCmd.Flags().String("until", "", "Only show commits before a date")
+	logCmd.Flags().String("grep", "", "Only show commits whose message matches a pattern (case-insensitive)")
+	logCmd.Flags().Bool("merges", false, "Only show merge commits")
+	logCmd.Flags().Bool("no-merges", false, "Hide merge commits")
+	logCmd.Flags().BoolP("interactive", "i", false, "Browse commits interactively with details and actions")
+	logCmd.Flags().BoolP("no-pager", "P", false, "Print the log without paging it")
+	logCmd.MarkFlagsMutuallyExclusive("merges", "no-merges")
 }
@@ -0,0 +1,452 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/spf13/viper"
+
+	"github.com/aixoio/tt/styles"
+)
+
+// logBrowser is a Bubble Tea model for browsing commits and acting on them
+type logBrowser struct {
+	entries   []LogEntry
+	visible   []int
+	cursor    int
+	offset    int
+	width     int
+	height    int
+	search    textinput.Model
+	searching bool
+	detail    bool
+	viewport  viewport.Model
+	status    string
+
+	// Set when the user picks an action; it runs after the program exits
+	action   string
+	selected LogEntry
+}
+
+const logBrowserHelp = "enter details • / search • c checkout • r revert • p cherry-pick • t tag • b branch • y copy hash • q quit"
+
+func newLogBrowser(entries []LogEntry) *logBrowser {
+	search := textinput.New()
+	search.Prompt = "/ "
+	search.Placeholder = "search subject, author, hash or refs"
+
+	m := &logBrowser{entries: entries, search: search, width: 80, height: 24}
+	m.applyFilter()
+	return m
+}
+
+func (m *logBrowser) Init() tea.Cmd {
+	return nil
+}
+
+func (m *logBrowser) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.viewport.Width, m.viewport.Height = msg.Width, m.listHeight()
+		m.scrollToCursor()
+		return m, nil
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		if m.searching {
+			return m.updateSearch(msg)
+		}
+		if m.detail {
+			return m.updateDetail(msg)
+		}
+		return m.updateList(msg)
+	}
+
+	return m, nil
+}
+
+func (m *logBrowser) updateSearch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		m.searching = false
+		m.search.Blur()
+		return m, nil
+	case "esc":
+		m.searching = false
+		m.search.Blur()
+		m.search.SetValue("")
+		m.applyFilter()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.search, cmd = m.search.Update(msg)
+	m.applyFilter()
+	return m, cmd
+}
+
+func (m *logBrowser) updateDetail(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "esc", "backspace":
+		m.detail = false
+		return m, nil
+	}
+
+	if done, cmd := m.handleAction(msg.String()); done {
+		return m, cmd
+	}
+
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+func (m *logBrowser) updateList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "esc":
+		return m, tea.Quit
+	case "up", "k":
+		m.move(-1)
+	case "down", "j":
+		m.move(1)
+	case "pgup":
+		m.move(-m.listHeight())
+	case "pgdown":
+		m.move(m.listHeight())
+	case "home", "g":
+		m.cursor = 0
+		m.move(0)
+	case "end", "G":
+		m.cursor = len(m.visible) - 1
+		m.move(0)
+	case "/":
+		m.searching = true
+		m.search.Focus()
+		return m, textinput.Blink
+	case "enter":
+		if entry, ok := m.current(); ok {
+			m.detail = true
+			m.viewport = viewport.New(m.width, m.listHeight())
+			m.viewport.SetContent(commitDetail(entry.Hash))
+		}
+	default:
+		if done, cmd := m.handleAction(msg.String()); done {
+			return m, cmd
+		}
+	}
+
+	return m, nil
+}
+
+// handleAction handles the single-key commit actions shared by the list and detail views
+func (m *logBrowser) handleAction(key string) (bool, tea.Cmd) {
+	entry, ok := m.current()
+	if !ok {
+		return false, nil
+	}
+
+	switch key {
+	case "y":
+		if err := clipboard.WriteAll(entry.Hash); err != nil {
+			m.status = styles.Error.Render("Could not copy hash: " + err.Error())
+		} else {
+			m.status = styles.Success.Render("Copied " + entry.ShortHash + " to clipboard")
+		}
+		return true, nil
+	case "c", "r", "p", "t", "b":
+		m.action = key
+		m.selected = entry
+		return true, tea.Quit
+	}
+
+	return false, nil
+}
+
+func (m *logBrowser) View() string {
+	if m.detail {
+		entry, _ := m.current()
+		header := styles.Primary.Render("Commit ") + styles.CommitHash.Render(entry.ShortHash) +
+			styles.Muted.Render(fmt.Sprintf("  %3.f%%", m.viewport.ScrollPercent()*100))
+		return header + "\n" + m.viewport.View() + "\n" + styles.Muted.Render("esc back • ↑/↓ scroll • c checkout • r revert • p cherry-pick • t tag • b branch • y copy hash")
+	}
+
+	var sb strings.Builder
+	sb.WriteString(styles.Primary.Render("Git Log") + styles.Muted.Render(fmt.Sprintf("  %d commits", m.commitCount())) + "\n")
+
+	end := min(m.offset+m.listHeight(), len(m.visible))
+	for i := m.offset; i < end; i++ {
+		entry := m.entries[m.visible[i]]
+		var line string
+		if entry.Hash == "" {
+			line = "  " + styles.Muted.Render(entry.Graph)
+		} else if i == m.cursor {
+			line = styles.Highlight.Render("▸ ") + formatLogEntry(entry)
+		} else {
+			line = "  " + formatLogEntry(entry)
+		}
+		sb.WriteString(ansi.Truncate(line, m.width, "…") + "\n")
+	}
+	for i := end - m.offset; i < m.listHeight(); i++ {
+		sb.WriteString("\n")
+	}
+
+	switch {
+	case m.searching || m.search.Value() != "":
+		sb.WriteString(m.search.View())
+	case m.status != "":
+		sb.WriteString(m.status)
+	default:
+		sb.WriteString(styles.Muted.Render(ansi.Truncate(logBrowserHelp, m.width, "…")))
+	}
+
+	return sb.String()
+}
+
+func (m *logBrowser) listHeight() int {
+	return max(m.height-2, 1)
+}
+
+func (m *logBrowser) commitCount() int {
+	count := 0
+	for _, i := range m.visible {
+		if m.entries[i].Hash != "" {
+			count++
+		}
+	}
+	return count
+}
+
+func (m *logBrowser) current() (LogEntry, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.visible) {
+		return LogEntry{}, false
+	}
+	entry := m.entries[m.visible[m.cursor]]
+	return entry, entry.Hash != ""
+}
+
+// applyFilter rebuilds the visible rows. Graph-only rows are hidden while searching
+// because the graph no longer lines up once commits are filtered out.
+func (m *logBrowser) applyFilter() {
+	query := strings.ToLower(strings.TrimSpace(m.search.Value()))
+	m.visible = m.visible[:0]
+	for i, entry := range m.entries {
+		if query == "" {
+			m.visible = append(m.visible, i)
+			continue
+		}
+		if entry.Hash == "" {
+			continue
+		}
+		haystack := strings.ToLower(entry.Subject + " " + entry.Author + " " + entry.Hash + " " + entry.Refs)
+		if strings.Contains(haystack, query) {
+			m.visible = append(m.visible, i)
+		}
+	}
+
+	m.cursor, m.offset = 0, 0
+	m.move(0)
+}
+
+// move steps the cursor by delta, skipping graph-only rows
+func (m *logBrowser) move(delta int) {
+	if len(m.visible) == 0 {
+		return
+	}
+
+	step := 1
+	if delta < 0 {
+		step = -1
+	}
+	target := min(max(m.cursor+delta, 0), len(m.visible)-1)
+	for i := target; i >= 0 && i < len(m.visible); i += step {
+		if m.entries[m.visible[i]].Hash != "" {
+			m.cursor = i
+			break
+		}
+	}
+	// Nothing selectable in that direction, look the other way from the target
+	if m.entries[m.visible[m.cursor]].Hash == "" {
+		for i := target; i >= 0 && i < len(m.visible); i -= step {
+			if m.entries[m.visible[i]].Hash != "" {
+				m.cursor = i
+				break
+			}
+		}
+	}
+
+	m.scrollToCursor()
+}
+
+func (m *logBrowser) scrollToCursor() {
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+m.listHeight() {
+		m.offset = m.cursor - m.listHeight() + 1
+	}
+}
+
+// commitDetail builds the message, stats and styled diff for a commit
+func commitDetail(hash string) string {
+	var sb strings.Builder
+
+	if output, err := exec.Command("git", "show", "--no-patch", "--format=%H%x00%an <%ae>%x00%ad%x00%B", hash).Output(); err == nil {
+		fields := strings.SplitN(string(output), "\x00", 4)
+		if len(fields) == 4 {
+			sb.WriteString(styles.CommitHash.Render(fields[0]) + "\n")
+			sb.WriteString(styles.Neutral.Render("Author: ") + styles.Highlight.Render(fields[1]) + "\n")
+			sb.WriteString(styles.Neutral.Render("Date:   ") + styles.Muted.Render(fields[2]) + "\n\n")
+			for line := range strings.SplitSeq(strings.TrimRight(fields[3], "\n"), "\n") {
+				sb.WriteString("    " + styles.Primary.Render(line) + "\n")
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	if stats, err := getRevertDiffStats(hash); err == nil {
+		sb.WriteString(stats + "\n\n")
+	}
+
+	if output, err := exec.Command("git", "show", "--format=", "--no-color", hash).Output(); err == nil {
+		sb.WriteString(renderDiffWith(string(output), diffRenderOptions{Syntax: syntaxHighlighterFromConfig()}))
+	}
+
+	return sb.String()
+}
+
+// runLogBrowser shows the interactive browser and runs the chosen action afterwards
+func runLogBrowser(opts LogOptions) error {
+	entries, err := getLogEntries(opts)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		fmt.Println(styles.InfoIcon + " " + styles.Info.Render("No commits found"))
+		return nil
+	}
+
+	browser := newLogBrowser(entries)
+	if _, err := tea.NewProgram(browser, tea.WithAltScreen()).Run(); err != nil {
+		return fmt.Errorf("failed to run log browser: %w", err)
+	}
+
+	if browser.action == "" {
+		return nil
+	}
+	return runLogAction(browser.action, browser.selected)
+}
+
+func runLogAction(action string, entry LogEntry) error {
+	sign := viper.GetBool("sign_commits")
+
+	fmt.Println(styles.Card.Render(
+		styles.Info.Render("Selected commit:") + "\n" +
+			styles.CommitHash.Render(entry.ShortHash) + " " + styles.Primary.Render(entry.Subject),
+	))
+
+	switch action {
+	case "c":
+		if !confirmLogAction("Checkout this commit?", "This will put you in 'detached HEAD' state.") {
+			return nil
+		}
+		return performCheckout(entry.Hash, "commit")
+
+	case "r":
+		if !confirmLogAction("Revert this commit?", "This will create a new commit that undoes its changes.") {
+			return nil
+		}
+		return performRevert(entry.Hash, sign)
+
+	case "p":
+		if !confirmLogAction("Cherry-pick this commit?", "This will apply its changes on top of the current branch.") {
+			return nil
+		}
+		fmt.Print(styles.SpinnerIcon + " " + styles.Info.Render("Cherry-picking... "))
+		pickCmd := exec.Command("git", withSigning(sign, "-S", "cherry-pick", entry.Hash)...)
+		pickCmd.Stdout = os.Stdout
+		pickCmd.Stderr = os.Stderr
+		if err := pickCmd.Run(); err != nil {
+			fmt.Println(styles.ErrorIcon)
+			fmt.Println(styles.WarningIcon + " " + styles.Warning.Render("Resolve any conflicts, then run 'git cherry-pick --continue'."))
+			return fmt.Errorf("cherry-pick failed: %w", err)
+		}
+		fmt.Println(styles.SuccessIcon)
+		return nil
+
+	case "t":
+		var name, message string
+		form := huh.NewForm(
+			huh.NewGroup(
+				huh.NewInput().
+					Title(styles.Primary.Render("Tag Name")).
+					Placeholder("v1.0.0").
+					Value(&name).
+					Validate(func(s string) error {
+						if strings.TrimSpace(s) == "" {
+							return fmt.Errorf("tag name cannot be empty")
+						}
+						return nil
+					}),
+				huh.NewInput().
+					Title(styles.Primary.Render("Tag Message")).
+					Description("Leave empty for a lightweight tag").
+					Value(&message),
+			),
+		).WithTheme(huh.ThemeCharm())
+		if err := form.Run(); err != nil {
+			return fmt.Errorf("failed to get tag details: %w", err)
+		}
+		return createTag(strings.TrimSpace(name), entry.Hash, message, viper.GetBool("sign_tags"))
+
+	case "b":
+		var name string
+		form := huh.NewForm(
+			huh.NewGroup(
+				huh.NewInput().
+					Title(styles.Primary.Render("Branch Name")).
+					Placeholder("feature/my-branch").
+					Value(&name).
+					Validate(func(s string) error {
+						if strings.TrimSpace(s) == "" {
+							return fmt.Errorf("branch name cannot be empty")
+						}
+						return nil
+					}),
+			),
+		).WithTheme(huh.ThemeCharm())
+		if err := form.Run(); err != nil {
+			return fmt.Errorf("failed to get branch name: %w", err)
+		}
+		return createBranch(strings.TrimSpace(name), entry.Hash, false)
+	}
+
+	return nil
+}
+
+func confirmLogAction(title, description string) bool {
+	var confirm bool
+	prompt := huh.NewConfirm().
+		Title(styles.WarningIcon + " " + styles.Warning.Render(title)).
+		Description(description).
+		Value(&confirm).
+		Affirmative("Yes").
+		Negative("No").
+		WithTheme(huh.ThemeCharm())
+
+	if err := prompt.Run(); err != nil || !confirm {
+		fmt.Println(styles.InfoIcon + " " + styles.Info.Render("Cancelled"))
+		return false
+	}
+	return true
+}
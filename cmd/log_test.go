@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseLogLine(t *testing.T) {
+	entry := parseLogLine("* \x1fabc123def\x00abc123d\x00HEAD -> main\x00Ada\x002 days ago\x00G\x00feat: parse (with parens)")
+	if entry.Graph != "* " {
+		t.Errorf("expected graph prefix '* ', got %q", entry.Graph)
+	}
+	if entry.ShortHash != "abc123d" || entry.Refs != "HEAD -> main" || entry.Author != "Ada" {
+		t.Errorf("unexpected entry %+v", entry)
+	}
+	if entry.Subject != "feat: parse (with parens)" {
+		t.Errorf("unexpected subject %q", entry.Subject)
+	}
+
+	graphOnly := parseLogLine("|\\")
+	if graphOnly.Hash != "" || graphOnly.Graph != "|\\" {
+		t.Errorf("expected graph-only line, got %+v", graphOnly)
+	}
+}
+
+func TestBuildLogArgs(t *testing.T) {
+	args := buildLogArgs(LogOptions{
+		Count:     5,
+		NoMerges:  true,
+		Author:    "ada",
+		Revisions: []string{"v1.0.0..HEAD"},
+		Paths:     []string{"cmd/"},
+	})
+
+	for _, want := range []string{"-n5", "--no-merges", "--author=ada", "v1.0.0..HEAD"} {
+		if !slices.Contains(args, want) {
+			t.Errorf("expected %q in %v", want, args)
+		}
+	}
+	if slices.Contains(args, "--reverse") {
+		t.Error("expected newest-first order by default")
+	}
+	if args[len(args)-2] != "--" || args[len(args)-1] != "cmd/" {
+		t.Errorf("expected paths after --, got %v", args)
+	}
+}
@@ -0,0 +1,231 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/aixoio/tt/styles"
+)
+
+// emptyTreeHash is the hash of git's empty tree, used to diff from the first commit
+const emptyTreeHash = "4b825dc642cb6eb9a060e54bf8d69288fbee4904"
+
+var notesCmd = &cobra.Command{
+	Use:     "notes [<from>..<to>]",
+	Aliases: []string{"release-notes"},
+	Short:   "Generate AI-written release notes between two refs",
+	Long:    styles.Info.Render("Collect the commits and diffstat between two refs (default: last tag..HEAD) and ask the configured model for audience-oriented release notes."),
+	Args:    cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		modelFlag, _ := cmd.Flags().GetString("model")
+		outputPath, _ := cmd.Flags().GetString("output")
+		tagName, _ := cmd.Flags().GetString("tag")
+		force, _ := cmd.Flags().GetBool("force")
+		raw, _ := cmd.Flags().GetBool("raw")
+
+		apiKey := viper.GetString("api_key")
+		if apiKey == "" {
+			fmt.Println(styles.ErrorIcon + " " + styles.Error.Render("API key not set. Run 'tt set' to configure it."))
+			return fmt.Errorf("API key not set")
+		}
+
+		// Show header
+		fmt.Println(styles.Header.Render("Release Notes"))
+		fmt.Println()
+
+		// Check if we're in a git repository
+		if _, err := exec.Command("git", "rev-parse", "--git-dir").Output(); err != nil {
+			fmt.Println(styles.ErrorIcon + " " + styles.Error.Render("Not a git repository"))
+			return fmt.Errorf("not a git repository")
+		}
+
+		spec := ""
+		if len(args) > 0 {
+			spec = args[0]
+		}
+		from, to, err := resolveNotesRange(spec)
+		if err != nil {
+			return err
+		}
+
+		fromLabel := from
+		if fromLabel == "" {
+			fromLabel = "the first commit"
+		}
+		fmt.Println(styles.InfoIcon + " " + styles.Info.Render("Range: ") + styles.Branch.Render(fromLabel) + " → " + styles.Branch.Render(to))
+
+		fmt.Print(styles.InfoIcon + " " + styles.Info.Render("Collecting commits... "))
+		prompt, commitCount, err := buildNotesPrompt(from, to)
+		if err != nil {
+			fmt.Println(styles.ErrorIcon)
+			return err
+		}
+		if commitCount == 0 {
+			fmt.Println(styles.WarningIcon)
+			fmt.Println(styles.InfoIcon + " " + styles.Info.Render("No commits found in range"))
+			return nil
+		}
+		fmt.Println(styles.SuccessIcon)
+
+		modelToUse := modelFlag
+		if modelToUse == "" {
+			modelToUse = viper.GetString("default_model")
+		}
+		fmt.Printf("%s %s: %s\n\n", styles.InfoIcon, styles.Info.Render("Using model"), styles.Highlight.Render(modelToUse))
+
+		notes, err := runWithSpinnerForMessage("📝 Writing release notes...", func() (string, error) {
+			return generateAIResponse(apiKey, viper.GetString("base_url"), modelToUse, prompt)
+		})
+		if err != nil {
+			fmt.Println(styles.ErrorIcon + " " + styles.Error.Render("Failed to generate release notes"))
+			return err
+		}
+
+		if outputPath != "" {
+			if err := os.WriteFile(outputPath, []byte(notes+"\n"), 0644); err != nil {
+				return fmt.Errorf("failed to write release notes: %w", err)
+			}
+			fmt.Println(styles.SuccessIcon + " " + styles.Success.Render("Release notes written to ") + styles.FilePath.Render(outputPath))
+		}
+
+		if tagName != "" {
+			if err := annotateTagWithNotes(tagName, to, notes, force); err != nil {
+				return err
+			}
+			fmt.Println(styles.SuccessIcon + " " + styles.Success.Render("Release notes stored in tag ") + styles.Branch.Render(tagName))
+		}
+
+		if outputPath == "" && tagName == "" {
+			fmt.Println()
+			if raw {
+				fmt.Println(notes)
+			} else {
+				printMarkdown(notes)
+			}
+		}
+
+		return nil
+	},
+}
+
+// resolveNotesRange turns "<from>..<to>", "<from>" or "" into a pair of refs.
+// An empty from means the range starts at the first commit.
+func resolveNotesRange(spec string) (string, string, error) {
+	from, to := spec, "HEAD"
+	if before, after, found := strings.Cut(spec, ".."); found {
+		from, to = before, strings.TrimPrefix(after, ".")
+		if to == "" {
+			to = "HEAD"
+		}
+	}
+
+	if from == "" {
+		// Look from the parent so a tag on <to> itself does not produce an empty range
+		output, err := exec.Command("git", "describe", "--tags", "--abbrev=0", to+"^").Output()
+		if err == nil {
+			from = strings.TrimSpace(string(output))
+		}
+	}
+
+	for _, ref := range []string{from, to} {
+		if ref == "" {
+			continue
+		}
+		if err := exec.Command("git", "rev-parse", "--verify", "--quiet", ref+"^{commit}").Run(); err != nil {
+			return "", "", fmt.Errorf("unknown revision '%s'", ref)
+		}
+	}
+
+	return from, to, nil
+}
+
+// buildNotesPrompt collects commit subjects, bodies, authors and the diffstat for a range
+func buildNotesPrompt(from, to string) (string, int, error) {
+	logRange := to
+	diffFrom := emptyTreeHash
+	if from != "" {
+		logRange = from + ".." + to
+		diffFrom = from
+	}
+
+	logOutput, err := exec.Command("git", "log", "--no-merges", "--format=%h%x1f%an%x1f%s%x1f%b%x1e", logRange).Output()
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to get commits: %w", err)
+	}
+
+	var commits strings.Builder
+	var contributors []string
+	count := 0
+	for record := range strings.SplitSeq(string(logOutput), "\x1e") {
+		fields := strings.Split(strings.TrimSpace(record), "\x1f")
+		if len(fields) < 4 {
+			continue
+		}
+		count++
+
+		commits.WriteString("- " + fields[0] + " " + fields[2] + " (" + fields[1] + ")\n")
+		if body := strings.TrimSpace(fields[3]); body != "" {
+			for line := range strings.SplitSeq(body, "\n") {
+				commits.WriteString("    " + line + "\n")
+			}
+		}
+
+		if !slices.Contains(contributors, fields[1]) {
+			contributors = append(contributors, fields[1])
+		}
+	}
+
+	statOutput, err := exec.Command("git", "diff", "--stat", diffFrom, to).Output()
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to get diffstat: %w", err)
+	}
+
+	data := newPromptData("", nil)
+	data.Commits = commits.String()
+	data.Stat = string(statOutput)
+	data.Contributors = contributors
+	prompt, err := renderPrompt("notes", data)
+	if err != nil {
+		return "", 0, err
+	}
+	return prompt, count, nil
+}
+
+// annotateTagWithNotes creates an annotated tag at ref with the notes as its message
+func annotateTagWithNotes(name, ref, notes string, force bool) error {
+	if !force {
+		if output, err := exec.Command("git", "tag", "-l", name).Output(); err == nil && strings.TrimSpace(string(output)) != "" {
+			return fmt.Errorf("tag '%s' already exists, use --force to replace its annotation", name)
+		}
+	}
+
+	args := withSigning(viper.GetBool("sign_tags"), "-s", "tag", "-a", name, "-m", notes)
+	if force {
+		args = append(args, "-f")
+		// Keep the tag on the commit it already points to
+		if output, err := exec.Command("git", "rev-parse", "--verify", "--quiet", name+"^{commit}").Output(); err == nil {
+			ref = strings.TrimSpace(string(output))
+		}
+	}
+	args = append(args, ref)
+
+	if output, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to annotate tag: %v", string(output))
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(notesCmd)
+	notesCmd.Flags().StringP("model", "m", "", "Model to use for generation (overrides default_model from config)")
+	notesCmd.Flags().StringP("output", "o", "", "Write the release notes to a file")
+	notesCmd.Flags().StringP("tag", "t", "", "Store the release notes as the annotation of this tag")
+	notesCmd.Flags().BoolP("force", "f", false, "Replace the annotation of an existing tag")
+	notesCmd.Flags().Bool("raw", false, "Print raw markdown instead of rendering it")
+}
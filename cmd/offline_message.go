@@ -0,0 +1,352 @@
+package cmd
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// maxOfflineSubject keeps generated subjects within the usual commit subject width
+const maxOfflineSubject = 72
+
+// symbolPatterns match declarations in common languages; the first group is the name
+var symbolPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`^func\s+(?:\([^)]*\)\s*)?([A-Za-z_]\w*)`),                                     // Go
+	regexp.MustCompile(`^type\s+([A-Za-z_]\w*)\s+(?:struct|interface)`),                               // Go
+	regexp.MustCompile(`^\s*(?:async\s+)?def\s+([A-Za-z_]\w*)`),                                       // Python
+	regexp.MustCompile(`^\s*class\s+([A-Za-z_]\w*)`),                                                  // Python, JS, Java
+	regexp.MustCompile(`^(?:export\s+)?(?:default\s+)?(?:async\s+)?function\*?\s+([A-Za-z_$][\w$]*)`), // JS/TS
+	regexp.MustCompile(`^(?:export\s+)?const\s+([A-Za-z_$][\w$]*)\s*=\s*(?:async\s*)?\(`),             // JS/TS arrow functions
+	regexp.MustCompile(`^(?:export\s+)?(?:interface|type)\s+([A-Za-z_$][\w$]*)`),                      // TS
+	regexp.MustCompile(`^\s*(?:pub(?:\([^)]*\))?\s+)?(?:async\s+)?fn\s+([A-Za-z_]\w*)`),               // Rust
+	regexp.MustCompile(`^\s*(?:pub(?:\([^)]*\))?\s+)?(?:struct|enum|trait)\s+([A-Za-z_]\w*)`),         // Rust
+}
+
+// goModRequireRe matches a dependency line in go.mod
+var goModRequireRe = regexp.MustCompile(`^(?:require\s+)?([\w.-]+\.[\w.-]+/\S+)\s+(v\S+)`)
+
+// dependencyFiles are manifests and lock files whose changes are dependency updates
+var dependencyFiles = []string{
+	"go.mod", "go.sum", "package.json", "package-lock.json", "yarn.lock", "pnpm-lock.yaml", "bun.lockb",
+	"Cargo.toml", "Cargo.lock", "requirements.txt", "poetry.lock", "Pipfile", "Pipfile.lock", "uv.lock",
+	"Gemfile", "Gemfile.lock", "composer.json", "composer.lock",
+}
+
+// offlineFile is what the heuristics need to know about one changed file
+type offlineFile struct {
+	Path    string
+	OldPath string
+	Kind    string // added, deleted, renamed or modified
+	Added   []string
+	Removed []string
+	Lines   []string
+}
+
+// offlineCommitMessage builds a conventional commit message from a diff without
+// asking a model. The type comes from what kind of files changed, the scope from
+// the directory they share and the subject from the declarations added or removed.
+func offlineCommitMessage(diff string) string {
+	var files []offlineFile
+	for _, file := range parseUnifiedDiff(diff) {
+		files = append(files, summarizeOfflineFile(file))
+	}
+	if len(files) == 0 {
+		return "chore: update files"
+	}
+
+	paths := make([]string, len(files))
+	for i, file := range files {
+		paths[i] = file.Path
+	}
+
+	commitType, scope := offlineType(paths), offlineScope(paths)
+	var subject string
+	switch commitType {
+	case "chore(deps)":
+		scope = ""
+		subject = dependencySubject(files)
+	case "docs", "test", "ci":
+		subject = fileSubject(files)
+	default:
+		subject = symbolSubject(files)
+		if subject == "" {
+			subject = fileSubject(files)
+		}
+		if commitType == "" {
+			commitType = offlineTypeFromChanges(files)
+		}
+	}
+
+	prefix := commitType
+	if scope != "" {
+		prefix += "(" + scope + ")"
+	}
+	message := prefix + ": " + subject
+	// Cut on rune boundaries so non-ASCII file and symbol names stay valid UTF-8
+	if runes := []rune(message); len(runes) > maxOfflineSubject {
+		message = strings.TrimSpace(string(runes[:maxOfflineSubject-3])) + "..."
+	}
+	return message
+}
+
+func summarizeOfflineFile(file DiffFile) offlineFile {
+	summary := offlineFile{Path: file.Path(), OldPath: file.OldPath, Kind: "modified"}
+	for _, line := range file.Header {
+		switch {
+		case strings.HasPrefix(line, "new file mode"):
+			summary.Kind = "added"
+		case strings.HasPrefix(line, "deleted file mode"):
+			summary.Kind = "deleted"
+		case strings.HasPrefix(line, "rename from"):
+			summary.Kind = "renamed"
+		}
+	}
+
+	added, removed := map[string]bool{}, map[string]bool{}
+	for _, hunk := range file.Hunks {
+		for _, line := range hunk.Lines {
+			if line == "" || (line[0] != '+' && line[0] != '-') {
+				continue
+			}
+			summary.Lines = append(summary.Lines, line)
+			name := declaredSymbol(line[1:])
+			if name == "" {
+				continue
+			}
+			if line[0] == '+' {
+				added[name] = true
+			} else {
+				removed[name] = true
+			}
+		}
+	}
+
+	// A declaration on both sides was edited in place, so it is neither new nor gone
+	for name := range added {
+		if removed[name] {
+			delete(added, name)
+			delete(removed, name)
+		}
+	}
+	summary.Added = sortedKeys(added)
+	summary.Removed = sortedKeys(removed)
+	return summary
+}
+
+func declaredSymbol(line string) string {
+	for _, pattern := range symbolPatterns {
+		if matches := pattern.FindStringSubmatch(line); matches != nil {
+			return matches[1]
+		}
+	}
+	return ""
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// offlineType picks a type when every path is of one kind, or "" to decide from the changes
+func offlineType(paths []string) string {
+	switch {
+	case allPaths(paths, isDependencyFile):
+		return "chore(deps)"
+	case allPaths(paths, isTestFile):
+		return "test"
+	case allPaths(paths, isDocFile):
+		return "docs"
+	case allPaths(paths, isCIFile):
+		return "ci"
+	}
+	return ""
+}
+
+// offlineTypeFromChanges calls new code a feature, pure removals a refactor and anything else a fix
+func offlineTypeFromChanges(files []offlineFile) string {
+	var added, removed bool
+	for _, file := range files {
+		added = added || file.Kind == "added" || len(file.Added) > 0
+		removed = removed || file.Kind == "deleted" || len(file.Removed) > 0
+	}
+	switch {
+	case added:
+		return "feat"
+	case removed:
+		return "refactor"
+	default:
+		return "fix"
+	}
+}
+
+func allPaths(paths []string, match func(string) bool) bool {
+	for _, p := range paths {
+		if !match(p) {
+			return false
+		}
+	}
+	return true
+}
+
+func isDependencyFile(p string) bool {
+	return slices.Contains(dependencyFiles, path.Base(p))
+}
+
+func isTestFile(p string) bool {
+	base := path.Base(p)
+	return strings.HasSuffix(base, "_test.go") || strings.HasPrefix(base, "test_") || strings.HasSuffix(base, "_test.py") ||
+		strings.Contains(base, ".test.") || strings.Contains(base, ".spec.") ||
+		slices.ContainsFunc(strings.Split(path.Dir(p), "/"), func(dir string) bool {
+			return dir == "test" || dir == "tests" || dir == "__tests__" || dir == "testdata"
+		})
+}
+
+func isDocFile(p string) bool {
+	switch strings.ToLower(path.Ext(p)) {
+	case ".md", ".mdx", ".rst", ".adoc", ".txt":
+		return true
+	}
+	return strings.HasPrefix(p, "docs/") || strings.HasPrefix(p, "doc/") || strings.HasPrefix(strings.ToUpper(path.Base(p)), "LICENSE")
+}
+
+func isCIFile(p string) bool {
+	return strings.HasPrefix(p, ".github/workflows/") || strings.HasPrefix(p, ".gitlab-ci") ||
+		strings.HasPrefix(p, ".circleci/") || strings.HasPrefix(p, ".gitea/workflows/") || p == ".travis.yml"
+}
+
+// offlineScope is the last directory shared by every path, skipping generic names
+func offlineScope(paths []string) string {
+	common := strings.Split(path.Dir(paths[0]), "/")
+	for _, p := range paths[1:] {
+		dirs := strings.Split(path.Dir(p), "/")
+		n := 0
+		for n < len(common) && n < len(dirs) && common[n] == dirs[n] {
+			n++
+		}
+		common = common[:n]
+	}
+
+	for i := len(common) - 1; i >= 0; i-- {
+		switch common[i] {
+		case ".", "", "src", "lib", "internal", "pkg", "app":
+			continue
+		}
+		return common[i]
+	}
+	return ""
+}
+
+func symbolSubject(files []offlineFile) string {
+	var added, removed []string
+	for _, file := range files {
+		added = append(added, file.Added...)
+		removed = append(removed, file.Removed...)
+	}
+	switch {
+	case len(added) > 0 && len(removed) > 0:
+		return "add " + joinNames(added) + ", remove " + joinNames(removed)
+	case len(added) > 0:
+		return "add " + joinNames(added)
+	case len(removed) > 0:
+		return "remove " + joinNames(removed)
+	}
+	return ""
+}
+
+func fileSubject(files []offlineFile) string {
+	if len(files) == 1 {
+		file := files[0]
+		name := path.Base(file.Path)
+		switch file.Kind {
+		case "added":
+			return "add " + name
+		case "deleted":
+			return "remove " + name
+		case "renamed":
+			return "rename " + path.Base(file.OldPath) + " to " + name
+		}
+		return "update " + name
+	}
+
+	kinds := map[string]int{}
+	for _, file := range files {
+		kinds[file.Kind]++
+	}
+	if kinds["added"] == len(files) {
+		return fmt.Sprintf("add %d files", len(files))
+	}
+	if kinds["deleted"] == len(files) {
+		return fmt.Sprintf("remove %d files", len(files))
+	}
+	var names []string
+	for _, file := range files {
+		names = append(names, path.Base(file.Path))
+	}
+	return "update " + joinNames(names)
+}
+
+// dependencySubject names the go.mod modules that changed, or falls back to a generic subject
+func dependencySubject(files []offlineFile) string {
+	oldVersions, newVersions := map[string]string{}, map[string]string{}
+	for _, file := range files {
+		if path.Base(file.Path) != "go.mod" {
+			continue
+		}
+		for _, line := range file.Lines {
+			matches := goModRequireRe.FindStringSubmatch(strings.TrimSpace(line[1:]))
+			if matches == nil {
+				continue
+			}
+			if line[0] == '+' {
+				newVersions[matches[1]] = matches[2]
+			} else {
+				oldVersions[matches[1]] = matches[2]
+			}
+		}
+	}
+
+	var changes []string
+	for _, module := range sortedKeys(boolSet(newVersions)) {
+		if oldVersions[module] != "" {
+			changes = append(changes, "bump "+module+" to "+newVersions[module])
+		} else {
+			changes = append(changes, "add "+module)
+		}
+	}
+	for _, module := range sortedKeys(boolSet(oldVersions)) {
+		if newVersions[module] == "" {
+			changes = append(changes, "remove "+module)
+		}
+	}
+
+	if len(changes) == 1 {
+		return changes[0]
+	}
+	return "update dependencies"
+}
+
+func boolSet(m map[string]string) map[string]bool {
+	set := make(map[string]bool, len(m))
+	for key := range m {
+		set[key] = true
+	}
+	return set
+}
+
+// joinNames lists up to three names, summarizing the rest
+func joinNames(names []string) string {
+	names = slices.Compact(slices.Sorted(slices.Values(names)))
+	if len(names) > 3 {
+		return strings.Join(names[:2], ", ") + fmt.Sprintf(" and %d more", len(names)-2)
+	}
+	if len(names) > 1 {
+		return strings.Join(names[:len(names)-1], ", ") + " and " + names[len(names)-1]
+	}
+	return names[0]
+}
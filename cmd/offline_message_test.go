@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestOfflineCommitMessage(t *testing.T) {
+	tests := []struct {
+		name string
+		diff string
+		want string
+	}{
+		{
+			name: "new function",
+			diff: `diff --git a/cmd/usage.go b/cmd/usage.go
+index 1111111..2222222 100644
+--- a/cmd/usage.go
++++ b/cmd/usage.go
+@@ -10,3 +10,7 @@ func readUsage() {
+ }
++
++func summarizeUsage(entries []UsageEntry) []usageTotals {
++	return nil
++}
+`,
+			want: "feat(cmd): add summarizeUsage",
+		},
+		{
+			name: "removed types",
+			diff: `diff --git a/forge/forge.go b/forge/forge.go
+index 1111111..2222222 100644
+--- a/forge/forge.go
++++ b/forge/forge.go
+@@ -1,6 +1,2 @@
+-type legacyClient struct {
+-}
+-func oldHelper() {
+-}
+`,
+			want: "refactor(forge): remove legacyClient and oldHelper",
+		},
+		{
+			name: "edited function body",
+			diff: `diff --git a/main.go b/main.go
+index 1111111..2222222 100644
+--- a/main.go
++++ b/main.go
+@@ -1,3 +1,3 @@
+ func main() {
+-	run(1)
++	run(2)
+ }
+`,
+			want: "fix: update main.go",
+		},
+		{
+			name: "tests only",
+			diff: `diff --git a/cmd/log_test.go b/cmd/log_test.go
+index 1111111..2222222 100644
+--- a/cmd/log_test.go
++++ b/cmd/log_test.go
+@@ -1,2 +1,3 @@
++func TestGraph(t *testing.T) {}
+`,
+			want: "test(cmd): update log_test.go",
+		},
+		{
+			name: "docs",
+			diff: `diff --git a/README.md b/README.md
+index 1111111..2222222 100644
+--- a/README.md
++++ b/README.md
+@@ -1 +1 @@
+-# tt
++# tt - Git Helper Tool
+`,
+			want: "docs: update README.md",
+		},
+		{
+			name: "go module bump",
+			diff: `diff --git a/go.mod b/go.mod
+index 1111111..2222222 100644
+--- a/go.mod
++++ b/go.mod
+@@ -3,3 +3,3 @@
+ require (
+-	github.com/spf13/cobra v1.9.1
++	github.com/spf13/cobra v1.10.1
+ )
+diff --git a/go.sum b/go.sum
+index 1111111..2222222 100644
+--- a/go.sum
++++ b/go.sum
+@@ -1 +1 @@
+-github.com/spf13/cobra v1.9.1 h1:abc=
++github.com/spf13/cobra v1.10.1 h1:def=
+`,
+			want: "chore(deps): bump github.com/spf13/cobra to v1.10.1",
+		},
+		{
+			name: "new file without declarations",
+			diff: `diff --git a/styles/theme.yaml b/styles/theme.yaml
+new file mode 100644
+index 0000000..2222222
+--- /dev/null
++++ b/styles/theme.yaml
+@@ -0,0 +1 @@
++primary: "#7D56F4"
+`,
+			want: "feat(styles): add theme.yaml",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := offlineCommitMessage(tt.diff); got != tt.want {
+				t.Errorf("offlineCommitMessage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOfflineScope(t *testing.T) {
+	tests := []struct {
+		paths []string
+		want  string
+	}{
+		{[]string{"cmd/aic.go", "cmd/ai.go"}, "cmd"},
+		{[]string{"internal/forge/github.go", "internal/forge/gitlab.go"}, "forge"},
+		{[]string{"src/app.ts"}, ""},
+		{[]string{"cmd/aic.go", "styles/styles.go"}, ""},
+	}
+	for _, tt := range tests {
+		if got := offlineScope(tt.paths); got != tt.want {
+			t.Errorf("offlineScope(%v) = %q, want %q", tt.paths, got, tt.want)
+		}
+	}
+}
+
+func TestOfflineCommitMessageLongName(t *testing.T) {
+	name := "docs/" + strings.Repeat("é", 80) + ".md"
+	diff := "diff --git a/" + name + " b/" + name + "\nnew file mode 100644\nindex 0000000..2222222\n--- /dev/null\n+++ b/" + name + "\n@@ -0,0 +1 @@\n+hi\n"
+
+	got := offlineCommitMessage(diff)
+	if !utf8.ValidString(got) {
+		t.Errorf("offlineCommitMessage() = %q, not valid UTF-8", got)
+	}
+	if n := utf8.RuneCountInString(got); n != maxOfflineSubject || !strings.HasSuffix(got, "...") {
+		t.Errorf("offlineCommitMessage() = %q (%d runes), want it cut to %d with an ellipsis", got, n, maxOfflineSubject)
+	}
+}
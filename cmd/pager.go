@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/viper"
+	"golang.org/x/term"
+)
+
+// showOutput prints content, paging it when enabled, stdout is a terminal and the
+// content does not fit on one screen. The pager config chooses the built-in pager
+// (internal, the default), the git pager (external) or no paging at all (never).
+func showOutput(title, content string, enabled bool) error {
+	mode := viper.GetString("pager")
+	if !enabled || mode == "never" || fitsTerminal(content) {
+		fmt.Println(content)
+		return nil
+	}
+	if mode == "external" {
+		return pageOutput(content)
+	}
+	return runInternalPager(title, content)
+}
+
+// fitsTerminal reports whether content fits on one screen, which is always the case
+// when stdout is not a terminal
+func fitsTerminal(content string) bool {
+	fd := int(os.Stdout.Fd())
+	if !term.IsTerminal(fd) {
+		return true
+	}
+	_, height, err := term.GetSize(fd)
+	if err != nil || height <= 0 {
+		return true
+	}
+	return strings.Count(content, "\n")+1 < height
+}
+
+// pageOutput shows content through the user's git pager (core.pager, $GIT_PAGER or $PAGER),
+// falling back to printing it when stdout is not a terminal.
+func pageOutput(content string) error {
+	pager := gitPager()
+	if pager == "" || pager == "cat" || !term.IsTerminal(int(os.Stdout.Fd())) {
+		fmt.Println(content)
+		return nil
+	}
+
+	pagerCmd := exec.Command("sh", "-c", pager)
+	pagerCmd.Stdin = strings.NewReader(content + "\n")
+	pagerCmd.Stdout = os.Stdout
+	pagerCmd.Stderr = os.Stderr
+
+	// Same defaults git uses so colours pass through and short output doesn't page
+	pagerCmd.Env = os.Environ()
+	if os.Getenv("LESS") == "" {
+		pagerCmd.Env = append(pagerCmd.Env, "LESS=FRX")
+	}
+	if os.Getenv("LV") == "" {
+		pagerCmd.Env = append(pagerCmd.Env, "LV=-c")
+	}
+
+	if err := pagerCmd.Run(); err != nil {
+		return fmt.Errorf("failed to run pager: %w", err)
+	}
+	return nil
+}
+
+// gitPager returns the pager git would use
+func gitPager() string {
+	output, err := exec.Command("git", "var", "GIT_PAGER").Output()
+	if err != nil {
+		return "less"
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// terminalWidth returns the width of stdout, or 120 columns when it is not a terminal
+func terminalWidth() int {
+	width, _, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil || width <= 0 {
+		return 120
+	}
+	return width
+}
@@ -0,0 +1,331 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/aixoio/tt/styles"
+)
+
+// pagerSection marks the line where a file starts in paged diff output
+type pagerSection struct {
+	Title string
+	Line  int
+}
+
+// pagerModel is a Bubble Tea pager for styled output with file and hunk
+// navigation when the content is a diff
+type pagerModel struct {
+	title     string
+	lines     []string
+	plain     []string
+	files     []pagerSection
+	hunks     []int
+	offset    int
+	width     int
+	height    int
+	sidebar   bool
+	search    textinput.Model
+	searching bool
+	matches   []int
+	status    string
+}
+
+const pagerHelp = "↑/↓ scroll • space/b page • ]/[ file • }/{ hunk • tab files • / search • n/N match • q quit"
+
+func newPagerModel(title, content string) *pagerModel {
+	search := textinput.New()
+	search.Prompt = "/ "
+	search.Placeholder = "search"
+
+	m := &pagerModel{title: title, search: search, width: 80, height: 24}
+	m.lines = strings.Split(content, "\n")
+	m.plain = make([]string, len(m.lines))
+	for i, line := range m.lines {
+		plain := ansi.Strip(line)
+		m.plain[i] = strings.ToLower(plain)
+
+		// Headers are the only lines that start at column 0 with these prefixes,
+		// every code line begins with its +/-/space marker or a line number
+		switch {
+		case strings.HasPrefix(plain, "diff --git ") || strings.HasPrefix(plain, "diff --cc ") || strings.HasPrefix(plain, "diff --combined "):
+			_, path := parseDiffGitPaths(plain)
+			m.files = append(m.files, pagerSection{Title: path, Line: i})
+		case strings.HasPrefix(plain, "@@"):
+			m.hunks = append(m.hunks, i)
+		}
+	}
+	m.sidebar = len(m.files) > 1
+	return m
+}
+
+// runInternalPager shows content in the built-in pager until the user quits
+func runInternalPager(title, content string) error {
+	if _, err := tea.NewProgram(newPagerModel(title, content), tea.WithAltScreen()).Run(); err != nil {
+		return fmt.Errorf("pager failed: %w", err)
+	}
+	return nil
+}
+
+func (m *pagerModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m *pagerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.scrollTo(m.offset)
+		return m, nil
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		if m.searching {
+			return m.updateSearch(msg)
+		}
+		return m.updateKeys(msg)
+	}
+
+	return m, nil
+}
+
+func (m *pagerModel) updateSearch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		m.searching = false
+		m.search.Blur()
+		m.findMatches()
+		m.nextMatch(m.offset, 1)
+		return m, nil
+	case "esc":
+		m.searching = false
+		m.search.Blur()
+		m.search.SetValue("")
+		m.matches = nil
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.search, cmd = m.search.Update(msg)
+	return m, cmd
+}
+
+func (m *pagerModel) updateKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.status = ""
+	switch msg.String() {
+	case "q":
+		return m, tea.Quit
+	case "esc":
+		if len(m.matches) == 0 {
+			return m, tea.Quit
+		}
+		m.search.SetValue("")
+		m.matches = nil
+	case "up", "k":
+		m.scrollTo(m.offset - 1)
+	case "down", "j", "enter":
+		m.scrollTo(m.offset + 1)
+	case "pgup", "b":
+		m.scrollTo(m.offset - m.bodyHeight())
+	case "pgdown", " ", "f":
+		m.scrollTo(m.offset + m.bodyHeight())
+	case "u", "ctrl+u":
+		m.scrollTo(m.offset - m.bodyHeight()/2)
+	case "d", "ctrl+d":
+		m.scrollTo(m.offset + m.bodyHeight()/2)
+	case "home", "g":
+		m.scrollTo(0)
+	case "end", "G":
+		m.scrollTo(len(m.lines))
+	case "]":
+		m.jump(sectionLines(m.files), 1, "file")
+	case "[":
+		m.jump(sectionLines(m.files), -1, "file")
+	case "}":
+		m.jump(m.hunks, 1, "hunk")
+	case "{":
+		m.jump(m.hunks, -1, "hunk")
+	case "tab":
+		if len(m.files) > 0 {
+			m.sidebar = !m.sidebar
+		}
+	case "/":
+		m.searching = true
+		m.search.Focus()
+		return m, textinput.Blink
+	case "n":
+		m.nextMatch(m.offset+1, 1)
+	case "N":
+		m.nextMatch(m.offset-1, -1)
+	}
+
+	return m, nil
+}
+
+func sectionLines(sections []pagerSection) []int {
+	lines := make([]int, len(sections))
+	for i, section := range sections {
+		lines[i] = section.Line
+	}
+	return lines
+}
+
+// jump scrolls to the next (dir 1) or previous (dir -1) mark relative to the top line
+func (m *pagerModel) jump(marks []int, dir int, name string) {
+	if dir > 0 {
+		for _, line := range marks {
+			if line > m.offset {
+				m.scrollTo(line)
+				return
+			}
+		}
+	} else {
+		for i := len(marks) - 1; i >= 0; i-- {
+			if marks[i] < m.offset {
+				m.scrollTo(marks[i])
+				return
+			}
+		}
+	}
+	if len(marks) == 0 {
+		m.status = styles.Muted.Render("No " + name + "s in this output")
+	} else {
+		m.status = styles.Muted.Render("No more " + name + "s")
+	}
+}
+
+// findMatches records every line containing the search query, ignoring case and styling
+func (m *pagerModel) findMatches() {
+	m.matches = nil
+	query := strings.ToLower(m.search.Value())
+	if query == "" {
+		return
+	}
+	for i, line := range m.plain {
+		if strings.Contains(line, query) {
+			m.matches = append(m.matches, i)
+		}
+	}
+}
+
+// nextMatch scrolls to the first match at or after from (dir 1), or at or before it (dir -1)
+func (m *pagerModel) nextMatch(from, dir int) {
+	if m.search.Value() == "" {
+		return
+	}
+	if len(m.matches) == 0 {
+		m.status = styles.Warning.Render("Pattern not found: " + m.search.Value())
+		return
+	}
+
+	for i := range m.matches {
+		index := i
+		if dir < 0 {
+			index = len(m.matches) - 1 - i
+		}
+		line := m.matches[index]
+		if (dir > 0 && line >= from) || (dir < 0 && line <= from) {
+			m.scrollTo(line)
+			m.status = styles.Info.Render(fmt.Sprintf("Match %d of %d", index+1, len(m.matches)))
+			return
+		}
+	}
+	m.status = styles.Muted.Render("No more matches")
+}
+
+func (m *pagerModel) scrollTo(line int) {
+	m.offset = max(min(line, len(m.lines)-m.bodyHeight()), 0)
+}
+
+func (m *pagerModel) bodyHeight() int {
+	return max(m.height-2, 1)
+}
+
+func (m *pagerModel) sidebarWidth() int {
+	if !m.sidebar {
+		return 0
+	}
+	return min(max(m.width/4, 16), 40)
+}
+
+// currentFile returns the index of the file shown at the top of the screen, or -1
+func (m *pagerModel) currentFile() int {
+	current := -1
+	for i, file := range m.files {
+		if file.Line <= m.offset {
+			current = i
+		}
+	}
+	return current
+}
+
+func (m *pagerModel) View() string {
+	var sb strings.Builder
+
+	header := styles.Primary.Render(m.title)
+	if current := m.currentFile(); current >= 0 {
+		header += styles.Muted.Render(fmt.Sprintf("  file %d/%d  ", current+1, len(m.files))) + styles.FilePath.Render(m.files[current].Title)
+	}
+	percent := 100
+	if len(m.lines) > m.bodyHeight() {
+		percent = m.offset * 100 / (len(m.lines) - m.bodyHeight())
+	}
+	header += styles.Muted.Render(fmt.Sprintf("  %d%%", percent))
+	sb.WriteString(ansi.Truncate(header, m.width, "…") + "\n")
+
+	sideWidth := m.sidebarWidth()
+	contentWidth := m.width
+	separator := styles.DiffGutter.Render(" │ ")
+	if sideWidth > 0 {
+		contentWidth = max(m.width-sideWidth-lipgloss.Width(separator), 1)
+	}
+
+	matched := map[int]bool{}
+	for _, line := range m.matches {
+		matched[line] = true
+	}
+
+	current := m.currentFile()
+	for row := range m.bodyHeight() {
+		if sideWidth > 0 {
+			var cell string
+			if row < len(m.files) {
+				name := ansi.Truncate(m.files[row].Title, sideWidth-2, "…")
+				if row == current {
+					cell = styles.Highlight.Render("▸ " + name)
+				} else {
+					cell = "  " + styles.FilePath.Render(name)
+				}
+			}
+			sb.WriteString(cell + strings.Repeat(" ", max(sideWidth-lipgloss.Width(cell), 0)) + separator)
+		}
+
+		index := m.offset + row
+		if index < len(m.lines) {
+			line := m.lines[index]
+			if matched[index] {
+				line = styles.Warning.Render("▌") + line
+			}
+			sb.WriteString(ansi.Truncate(line, contentWidth, "…"))
+		}
+		sb.WriteString("\n")
+	}
+
+	switch {
+	case m.searching:
+		sb.WriteString(m.search.View())
+	case m.status != "":
+		sb.WriteString(m.status)
+	default:
+		sb.WriteString(styles.Muted.Render(ansi.Truncate(pagerHelp, m.width, "…")))
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,436 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/huh"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/aixoio/tt/forge"
+	"github.com/aixoio/tt/styles"
+)
+
+// PRDescription is a generated pull request title and markdown body
+type PRDescription struct {
+	Title string
+	Body  string
+}
+
+// prTemplatePaths are the repo-local template files checked in order
+var prTemplatePaths = []string{
+	".tt/pull_request_template.md",
+	".github/pull_request_template.md",
+	".github/PULL_REQUEST_TEMPLATE.md",
+	"docs/pull_request_template.md",
+	"PULL_REQUEST_TEMPLATE.md",
+}
+
+// maxPRDiffBytes keeps the prompt within model limits on large branches
+const maxPRDiffBytes = 60000
+
+var prCmd = &cobra.Command{
+	Use:   "pr",
+	Short: "Work with pull requests for the current branch",
+	Long:  styles.Info.Render("Generate descriptions for and open pull requests from the current branch."),
+}
+
+var prDescribeCmd = &cobra.Command{
+	Use:   "describe",
+	Short: "Generate a pull request title and description",
+	Long:  styles.Info.Render("Collect the commits and diff since the current branch's merge-base with the default branch and ask the configured model for a PR title and a body with summary, changes, testing notes and risk, following the repository's PR template when there is one."),
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		modelFlag, _ := cmd.Flags().GetString("model")
+		base, _ := cmd.Flags().GetString("base")
+		templatePath, _ := cmd.Flags().GetString("template")
+		copyFlag, _ := cmd.Flags().GetBool("copy")
+		raw, _ := cmd.Flags().GetBool("raw")
+
+		apiKey := viper.GetString("api_key")
+		if apiKey == "" {
+			fmt.Println(styles.ErrorIcon + " " + styles.Error.Render("API key not set. Run 'tt set' to configure it."))
+			return fmt.Errorf("API key not set")
+		}
+
+		// Show header
+		fmt.Println(styles.Header.Render("Pull Request Description"))
+		fmt.Println()
+
+		if _, err := exec.Command("git", "rev-parse", "--git-dir").Output(); err != nil {
+			fmt.Println(styles.ErrorIcon + " " + styles.Error.Render("Not a git repository"))
+			return fmt.Errorf("not a git repository")
+		}
+
+		if base == "" {
+			defaultBranch, err := getDefaultBranch()
+			if err != nil {
+				return err
+			}
+			base = defaultBranch
+		}
+
+		template, templateSource, err := loadPRTemplate(templatePath)
+		if err != nil {
+			return err
+		}
+
+		branch, _ := getCurrentBranch()
+		fmt.Println(styles.InfoIcon + " " + styles.Info.Render("Comparing ") + styles.Branch.Render(branch) + " with " + styles.Branch.Render(base))
+		if templateSource != "" {
+			fmt.Println(styles.InfoIcon + " " + styles.Info.Render("Using template ") + styles.FilePath.Render(templateSource))
+		}
+
+		modelToUse := modelFlag
+		if modelToUse == "" {
+			modelToUse = viper.GetString("default_model")
+		}
+		fmt.Printf("%s %s: %s\n\n", styles.InfoIcon, styles.Info.Render("Using model"), styles.Highlight.Render(modelToUse))
+
+		var description PRDescription
+		err = runWithSpinner("📝 Writing PR description...", func() error {
+			var genErr error
+			description, genErr = generatePRDescription(apiKey, modelToUse, base, template)
+			return genErr
+		})
+		if err != nil {
+			fmt.Println(styles.ErrorIcon + " " + styles.Error.Render("Failed to generate PR description"))
+			return err
+		}
+
+		markdown := "# " + description.Title + "\n\n" + description.Body
+		if raw {
+			fmt.Println(markdown)
+		} else {
+			printMarkdown(markdown)
+		}
+
+		if copyFlag {
+			if err := clipboard.WriteAll(description.Title + "\n\n" + description.Body); err != nil {
+				return fmt.Errorf("failed to copy to clipboard: %w", err)
+			}
+			fmt.Println(styles.SuccessIcon + " " + styles.Success.Render("Copied title and description to clipboard"))
+		}
+
+		return nil
+	},
+}
+
+var prCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Push the current branch and open a pull request",
+	Long:  styles.Info.Render("Push the current branch, then open a pull request on GitHub, GitLab or Gitea (detected from the origin remote) with a generated or typed title and description, and print its URL."),
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		modelFlag, _ := cmd.Flags().GetString("model")
+		base, _ := cmd.Flags().GetString("base")
+		title, _ := cmd.Flags().GetString("title")
+		body, _ := cmd.Flags().GetString("body")
+		draft, _ := cmd.Flags().GetBool("draft")
+		noAI, _ := cmd.Flags().GetBool("no-ai")
+		noPush, _ := cmd.Flags().GetBool("no-push")
+		yes, _ := cmd.Flags().GetBool("yes")
+
+		// Show header
+		fmt.Println(styles.Header.Render("Create Pull Request"))
+		fmt.Println()
+
+		if _, err := exec.Command("git", "rev-parse", "--git-dir").Output(); err != nil {
+			fmt.Println(styles.ErrorIcon + " " + styles.Error.Render("Not a git repository"))
+			return fmt.Errorf("not a git repository")
+		}
+
+		branch, err := getCurrentBranch()
+		if err != nil || branch == "" {
+			return fmt.Errorf("not on a branch, check out the branch to open a pull request from")
+		}
+
+		if base == "" {
+			defaultBranch, err := getDefaultBranch()
+			if err != nil {
+				return err
+			}
+			base = defaultBranch
+		}
+		// The forge only knows branch names, not remote-tracking refs
+		baseName := strings.TrimPrefix(base, "origin/")
+		if baseName == branch {
+			return fmt.Errorf("'%s' is the base branch, create a feature branch first", branch)
+		}
+
+		client, err := newForgeClient()
+		if err != nil {
+			fmt.Println(styles.ErrorIcon + " " + styles.Error.Render("Forge not configured: "+err.Error()))
+			return err
+		}
+		fmt.Println(styles.InfoIcon + " " + styles.Info.Render("Opening a "+client.Name()+" pull request from ") +
+			styles.Branch.Render(branch) + " into " + styles.Branch.Render(baseName))
+
+		apiKey := viper.GetString("api_key")
+		if title == "" && !noAI && apiKey != "" {
+			template, _, err := loadPRTemplate("")
+			if err != nil {
+				return err
+			}
+			modelToUse := modelFlag
+			if modelToUse == "" {
+				modelToUse = viper.GetString("default_model")
+			}
+
+			var description PRDescription
+			err = runWithSpinner("📝 Writing PR description...", func() error {
+				var genErr error
+				description, genErr = generatePRDescription(apiKey, modelToUse, base, template)
+				return genErr
+			})
+			if err != nil {
+				fmt.Println(styles.WarningIcon + " " + styles.Warning.Render("Could not generate a description: "+err.Error()))
+			} else {
+				title, body = description.Title, description.Body
+			}
+		}
+		if title == "" {
+			// Fall back to the latest commit subject, like the forges' own web forms
+			if output, err := exec.Command("git", "log", "-1", "--format=%s").Output(); err == nil {
+				title = strings.TrimSpace(string(output))
+			}
+		}
+
+		if !yes {
+			confirmed := true
+			form := huh.NewForm(
+				huh.NewGroup(
+					huh.NewInput().
+						Title(styles.Primary.Render("Title")).
+						Value(&title).
+						Validate(func(s string) error {
+							if strings.TrimSpace(s) == "" {
+								return fmt.Errorf("title cannot be empty")
+							}
+							return nil
+						}),
+					huh.NewText().
+						Title(styles.Primary.Render("Description")).
+						Description("Markdown").
+						Lines(12).
+						Value(&body),
+					huh.NewConfirm().
+						Title("Open this pull request?").
+						Affirmative("Open").
+						Negative("Cancel").
+						Value(&confirmed),
+				),
+			).WithTheme(huh.ThemeCharm())
+
+			if err := form.Run(); err != nil {
+				return fmt.Errorf("failed to edit pull request: %w", err)
+			}
+			if !confirmed {
+				fmt.Println(styles.InfoIcon + " " + styles.Info.Render("Pull request cancelled"))
+				return nil
+			}
+		}
+		if strings.TrimSpace(title) == "" {
+			return fmt.Errorf("pull request title cannot be empty, pass --title")
+		}
+
+		if !noPush {
+			if err := pushChanges(); err != nil {
+				return err
+			}
+		}
+
+		var result *forge.PullRequestResult
+		err = runWithSpinner(styles.InfoIcon+" "+styles.Info.Render("Opening pull request..."), func() error {
+			var createErr error
+			result, createErr = client.CreatePullRequest(context.Background(), forge.PullRequest{
+				Title: strings.TrimSpace(title),
+				Body:  body,
+				Head:  branch,
+				Base:  baseName,
+				Draft: draft,
+			})
+			return createErr
+		})
+		if err != nil {
+			fmt.Println(styles.ErrorIcon + " " + styles.Error.Render("Failed to open pull request"))
+			return err
+		}
+
+		fmt.Println(styles.Card.Render(
+			styles.Success.Render(fmt.Sprintf("Pull request #%d opened!", result.Number)) + "\n" +
+				styles.Neutral.Render("Title: ") + styles.Primary.Render(strings.TrimSpace(title)) + "\n" +
+				styles.Neutral.Render("URL: ") + styles.Highlight.Render(result.URL),
+		))
+		return nil
+	},
+}
+
+// forgeTokenEnv lists the environment variables holding each forge's token.
+// They are read directly instead of being bound in viper, which would write
+// them into the config file the next time it is saved.
+var forgeTokenEnv = map[string][]string{
+	"github": {"GITHUB_TOKEN", "GH_TOKEN"},
+	"gitlab": {"GITLAB_TOKEN"},
+	"gitea":  {"GITEA_TOKEN"},
+}
+
+// forgeToken prefers the environment over the <forge>_token config value
+func forgeToken(forgeType string) string {
+	for _, name := range forgeTokenEnv[forgeType] {
+		if token := os.Getenv(name); token != "" {
+			return token
+		}
+	}
+	return viper.GetString(forgeType + "_token")
+}
+
+// newForgeClient builds a forge client for the origin remote using the
+// forge_type, forge_api_url and <type>_token config values
+func newForgeClient() (forge.Forge, error) {
+	output, err := exec.Command("git", "remote", "get-url", "origin").Output()
+	if err != nil {
+		return nil, fmt.Errorf("no origin remote")
+	}
+	remote, err := forge.ParseRemoteURL(string(output))
+	if err != nil {
+		return nil, err
+	}
+
+	forgeType := viper.GetString("forge_type")
+	if forgeType == "" {
+		forgeType = forge.DetectType(remote.Host)
+	}
+	token := ""
+	if forgeType != "" {
+		token = forgeToken(forgeType)
+	}
+	if forgeType != "" && token == "" {
+		return nil, fmt.Errorf("set %s_token with 'tt set' or the environment", forgeType)
+	}
+
+	return forge.New(remote, forge.Options{
+		Type:   forgeType,
+		APIURL: viper.GetString("forge_api_url"),
+		Token:  token,
+	})
+}
+
+// loadPRTemplate reads an explicit template path, or the first repo-local template
+// found from the repository root. It returns the template and where it came from.
+func loadPRTemplate(path string) (string, string, error) {
+	if path != "" {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to read template: %w", err)
+		}
+		return string(content), path, nil
+	}
+
+	root, err := exec.Command("git", "rev-parse", "--show-toplevel").Output()
+	if err != nil {
+		return "", "", nil
+	}
+	for _, candidate := range prTemplatePaths {
+		if content, err := os.ReadFile(filepath.Join(strings.TrimSpace(string(root)), candidate)); err == nil {
+			return string(content), candidate, nil
+		}
+	}
+	return "", "", nil
+}
+
+// generatePRDescription describes the commits and diff between HEAD and its merge-base
+// with base. When template is not empty the body follows its structure.
+func generatePRDescription(apiKey, model, base, template string) (PRDescription, error) {
+	mergeBase, err := getMergeBase(base, "HEAD")
+	if err != nil {
+		return PRDescription{}, err
+	}
+
+	logOutput, err := exec.Command("git", "log", "--no-merges", "--format=%h%x1f%s%x1f%b%x1e", mergeBase+"..HEAD").Output()
+	if err != nil {
+		return PRDescription{}, fmt.Errorf("failed to get commits: %w", err)
+	}
+
+	var commits strings.Builder
+	for record := range strings.SplitSeq(string(logOutput), "\x1e") {
+		fields := strings.Split(strings.TrimSpace(record), "\x1f")
+		if len(fields) < 3 {
+			continue
+		}
+		commits.WriteString("- " + fields[0] + " " + fields[1] + "\n")
+		if body := strings.TrimSpace(fields[2]); body != "" {
+			for line := range strings.SplitSeq(body, "\n") {
+				commits.WriteString("    " + line + "\n")
+			}
+		}
+	}
+	if commits.Len() == 0 {
+		return PRDescription{}, fmt.Errorf("no commits on this branch since %s", base)
+	}
+
+	statOutput, err := exec.Command("git", "diff", "--stat", mergeBase, "HEAD").Output()
+	if err != nil {
+		return PRDescription{}, fmt.Errorf("failed to get diffstat: %w", err)
+	}
+	diffOutput, err := exec.Command("git", "diff", "--no-color", mergeBase, "HEAD").Output()
+	if err != nil {
+		return PRDescription{}, fmt.Errorf("failed to get diff: %w", err)
+	}
+	diff := truncateForPrompt(string(diffOutput), maxPRDiffBytes, "diff truncated, rely on the commits and diffstat for the rest")
+
+	data := newPromptData(diff, nil)
+	data.Commits = commits.String()
+	data.Stat = string(statOutput)
+	data.Template = template
+	prompt, err := renderPrompt("pr", data)
+	if err != nil {
+		return PRDescription{}, err
+	}
+
+	response, err := generateAIResponse(apiKey, viper.GetString("base_url"), model, prompt)
+	if err != nil {
+		return PRDescription{}, err
+	}
+	return parsePRDescription(response), nil
+}
+
+// headingMarkerRe matches a markdown heading marker, but not a "#123" reference
+var headingMarkerRe = regexp.MustCompile(`^\s*#+\s+`)
+
+// parsePRDescription splits a "# Title" first line from the body
+func parsePRDescription(response string) PRDescription {
+	response = strings.TrimSpace(response)
+	title, body, _ := strings.Cut(response, "\n")
+	return PRDescription{
+		Title: strings.TrimSpace(headingMarkerRe.ReplaceAllString(title, "")),
+		Body:  strings.TrimSpace(body),
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(prCmd)
+	prCmd.AddCommand(prDescribeCmd)
+	prCmd.AddCommand(prCreateCmd)
+	prDescribeCmd.Flags().StringP("model", "m", "", "Model to use for generation (overrides default_model from config)")
+	prDescribeCmd.Flags().StringP("base", "b", "", "Branch the pull request will target (default: the repository's default branch)")
+	prDescribeCmd.Flags().StringP("template", "t", "", "Markdown template to follow (default: the repository's pull request template)")
+	prDescribeCmd.Flags().BoolP("copy", "c", false, "Copy the title and description to the clipboard")
+	prDescribeCmd.Flags().Bool("raw", false, "Print raw markdown instead of rendering it")
+
+	prCreateCmd.Flags().StringP("model", "m", "", "Model to use for the description (overrides default_model from config)")
+	prCreateCmd.Flags().StringP("base", "b", "", "Branch to merge into (default: the repository's default branch)")
+	prCreateCmd.Flags().StringP("title", "t", "", "Pull request title (skips AI generation)")
+	prCreateCmd.Flags().String("body", "", "Pull request description")
+	prCreateCmd.Flags().BoolP("draft", "d", false, "Open the pull request as a draft")
+	prCreateCmd.Flags().Bool("no-ai", false, "Do not generate a description with AI")
+	prCreateCmd.Flags().Bool("no-push", false, "Do not push the branch first")
+	prCreateCmd.Flags().BoolP("yes", "y", false, "Open the pull request without reviewing the title and description")
+}
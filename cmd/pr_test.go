@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestParsePRDescription(t *testing.T) {
+	tests := []struct {
+		response string
+		want     string
+	}{
+		{"# Fix login\n\nBody", "Fix login"},
+		{"## Fix login\nBody", "Fix login"},
+		{"#123 fix login\nBody", "#123 fix login"},
+		{"Fix login\nBody", "Fix login"},
+	}
+	for _, tt := range tests {
+		got := parsePRDescription(tt.response)
+		if got.Title != tt.want {
+			t.Errorf("parsePRDescription(%q).Title = %q, want %q", tt.response, got.Title, tt.want)
+		}
+		if got.Body != "Body" {
+			t.Errorf("parsePRDescription(%q).Body = %q, want %q", tt.response, got.Body, "Body")
+		}
+	}
+}
+
+func TestForgeTokenNotSaved(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "ghp_secret123")
+	t.Setenv("GITEA_TOKEN", "")
+	viper.Set("gitea_token", "from-config")
+	t.Cleanup(func() { viper.Set("gitea_token", nil) })
+
+	if got := forgeToken("github"); got != "ghp_secret123" {
+		t.Errorf("forgeToken(github) = %q, want the environment value", got)
+	}
+	if got := forgeToken("gitea"); got != "from-config" {
+		t.Errorf("forgeToken(gitea) = %q, want the config value", got)
+	}
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := viper.WriteConfigAs(path); err != nil {
+		t.Fatalf("WriteConfigAs() error = %v", err)
+	}
+	written, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(written), "ghp_secret123") {
+		t.Errorf("the saved config contains the token from the environment:\n%s", written)
+	}
+}
@@ -0,0 +1,297 @@
+package cmd
+
+import (
+	"embed"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aixoio/tt/styles"
+)
+
+// builtinPrompts holds the default prompt templates shipped with tt
+//
+//go:embed prompts/*.tmpl
+var builtinPrompts embed.FS
+
+// PromptData holds the variables available to prompt templates. Fields a
+// feature does not use are left empty.
+type PromptData struct {
+	Diff          string
+	Files         []string
+	ProjectInfo   string
+	Branch        string
+	RecentCommits []string
+	Ticket        string
+	TicketURL     string
+
+	// Description says which changes a diff or review covers
+	Description string
+	// Message and Feedback are the current commit message and the user's notes on it
+	Message  string
+	Feedback string
+	// Rejected lists commit messages already turned down in this session
+	Rejected []string
+	// Commits, Stat and Contributors describe a range for PRs, notes and explain
+	Commits      string
+	Stat         string
+	Contributors []string
+	// Template is the repository's pull request template
+	Template string
+	// File, Line and FileContext locate the line being explained
+	File        string
+	Line        int
+	FileContext string
+}
+
+// promptTemplate names a prompt and the feature that uses it
+type promptTemplate struct {
+	Name        string
+	Description string
+}
+
+var promptTemplates = []promptTemplate{
+	{"commit", "Commit message for tt aic and tt ap"},
+	{"commit-detailed", "Longer commit message with a body (aic \"more detailed\")"},
+	{"commit-summarize", "Shorten the current commit message (aic \"summarize\")"},
+	{"commit-feedback", "Revise the commit message with your feedback (aic \"feedback\")"},
+	{"diff", "Overview for tt diff --ai"},
+	{"review", "Code review findings for tt review (must ask for the JSON shape)"},
+	{"pr", "Pull request title and description for tt pr describe/create"},
+	{"notes", "Release notes for tt notes"},
+	{"explain", "Explanation of a commit, range or line for tt explain"},
+	{"branch", "Branch name description suggested by tt branch --new"},
+}
+
+var promptFuncs = template.FuncMap{
+	"join":  strings.Join,
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"trim":  strings.TrimSpace,
+}
+
+// newPromptData fills the variables shared by every prompt from the repository
+// context: the project, branch, ticket and recent commit subjects
+func newPromptData(diff string, files []string) PromptData {
+	ctx := repoContext()
+	return PromptData{
+		Diff:          diff,
+		Files:         files,
+		ProjectInfo:   ctx.Summary(),
+		Branch:        ctx.Branch,
+		RecentCommits: ctx.RecentCommits,
+		Ticket:        ctx.Ticket,
+		TicketURL:     ctx.TicketURL,
+	}
+}
+
+// promptOverridePaths lists where a template may be overridden, most specific first
+func promptOverridePaths(name string) []string {
+	var paths []string
+	if root, err := exec.Command("git", "rev-parse", "--show-toplevel").Output(); err == nil {
+		paths = append(paths, filepath.Join(strings.TrimSpace(string(root)), ".tt", "prompts", name+".tmpl"))
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		paths = append(paths, filepath.Join(home, ".tt", "prompts", name+".tmpl"))
+	}
+	return paths
+}
+
+func isPromptName(name string) bool {
+	for _, prompt := range promptTemplates {
+		if prompt.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// loadPrompt returns the template text for a prompt and where it came from:
+// the repository's .tt/prompts, ~/.tt/prompts or the built-in default
+func loadPrompt(name string) (string, string, error) {
+	if !isPromptName(name) {
+		return "", "", fmt.Errorf("unknown prompt '%s', run 'tt prompts list' to see them", name)
+	}
+	for _, path := range promptOverridePaths(name) {
+		if content, err := os.ReadFile(path); err == nil {
+			return string(content), path, nil
+		}
+	}
+	content, err := builtinPrompts.ReadFile("prompts/" + name + ".tmpl")
+	if err != nil {
+		return "", "", err
+	}
+	return string(content), "built-in", nil
+}
+
+func parsePrompt(name, text string) (*template.Template, error) {
+	return template.New(name).Funcs(promptFuncs).Option("missingkey=error").Parse(text)
+}
+
+// renderPrompt executes a prompt template with the given data
+func renderPrompt(name string, data PromptData) (string, error) {
+	text, source, err := loadPrompt(name)
+	if err != nil {
+		return "", err
+	}
+	tmpl, err := parsePrompt(name, text)
+	if err != nil {
+		return "", fmt.Errorf("invalid prompt template %s: %w", source, err)
+	}
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, data); err != nil {
+		return "", fmt.Errorf("failed to render prompt template %s: %w", source, err)
+	}
+	return sb.String(), nil
+}
+
+var promptsCmd = &cobra.Command{
+	Use:   "prompts",
+	Short: "List, show and edit the prompts used by AI features",
+	Long:  styles.Info.Render("Every AI feature builds its prompt from a Go text/template. Override a built-in prompt by placing <name>.tmpl in the repository's .tt/prompts/ or in ~/.tt/prompts/; the repository's copy wins."),
+}
+
+var promptsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List prompts and where each one is loaded from",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		fmt.Println(styles.Header.Render("Prompts"))
+		fmt.Println()
+
+		for _, prompt := range promptTemplates {
+			_, source, err := loadPrompt(prompt.Name)
+			if err != nil {
+				return err
+			}
+			sourceStyle := styles.Muted
+			if source != "built-in" {
+				sourceStyle = styles.FilePath
+			}
+			fmt.Printf("%s %s\n", styles.Highlight.Render(fmt.Sprintf("%-18s", prompt.Name)), styles.Neutral.Render(prompt.Description))
+			fmt.Printf("%s %s\n", strings.Repeat(" ", 18), sourceStyle.Render(source))
+		}
+
+		fmt.Println()
+		fmt.Println(styles.InfoIcon + " " + styles.Muted.Render("Variables: .Diff .Files .ProjectInfo .Branch .RecentCommits .Ticket .TicketURL, plus .Description .Message .Feedback .Rejected .Commits .Stat .Contributors .Template .File .Line .FileContext where the feature provides them"))
+		return nil
+	},
+}
+
+var promptsShowCmd = &cobra.Command{
+	Use:   "show <name>",
+	Short: "Print the template a prompt uses",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		showDefault, _ := cmd.Flags().GetBool("default")
+
+		text, source, err := loadPrompt(args[0])
+		if err != nil {
+			return err
+		}
+		if showDefault {
+			content, err := builtinPrompts.ReadFile("prompts/" + args[0] + ".tmpl")
+			if err != nil {
+				return err
+			}
+			text, source = string(content), "built-in"
+		}
+
+		fmt.Println(styles.InfoIcon + " " + styles.Info.Render("Source: ") + styles.FilePath.Render(source))
+		fmt.Println()
+		fmt.Print(text)
+		return nil
+	},
+}
+
+var promptsEditCmd = &cobra.Command{
+	Use:   "edit <name>",
+	Short: "Open a prompt override in $EDITOR, creating it from the current template",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		repo, _ := cmd.Flags().GetBool("repo")
+		name := args[0]
+
+		text, _, err := loadPrompt(name)
+		if err != nil {
+			return err
+		}
+
+		var dir string
+		if repo {
+			root, err := exec.Command("git", "rev-parse", "--show-toplevel").Output()
+			if err != nil {
+				fmt.Println(styles.ErrorIcon + " " + styles.Error.Render("Not a git repository"))
+				return fmt.Errorf("not a git repository")
+			}
+			dir = filepath.Join(strings.TrimSpace(string(root)), ".tt", "prompts")
+		} else {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return err
+			}
+			dir = filepath.Join(home, ".tt", "prompts")
+		}
+		path := filepath.Join(dir, name+".tmpl")
+
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				return fmt.Errorf("failed to create %s: %w", dir, err)
+			}
+			if err := os.WriteFile(path, []byte(text), 0644); err != nil {
+				return fmt.Errorf("failed to write %s: %w", path, err)
+			}
+		}
+
+		if err := openEditor(path); err != nil {
+			return err
+		}
+
+		edited, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if _, err := parsePrompt(name, string(edited)); err != nil {
+			fmt.Println(styles.ErrorIcon + " " + styles.Error.Render("Template does not parse: "+err.Error()))
+			return fmt.Errorf("invalid prompt template")
+		}
+		fmt.Println(styles.SuccessIcon + " " + styles.Success.Render("Saved ") + styles.FilePath.Render(path))
+		return nil
+	},
+}
+
+// openEditor opens a file in $VISUAL or $EDITOR, falling back to vi
+func openEditor(path string) error {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+
+	// The editor may carry arguments, such as "code --wait"
+	fields := strings.Fields(editor)
+	editorCmd := exec.Command(fields[0], append(fields[1:], path)...)
+	editorCmd.Stdin = os.Stdin
+	editorCmd.Stdout = os.Stdout
+	editorCmd.Stderr = os.Stderr
+	if err := editorCmd.Run(); err != nil {
+		return fmt.Errorf("editor %s failed: %w", editor, err)
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(promptsCmd)
+	promptsCmd.AddCommand(promptsListCmd)
+	promptsCmd.AddCommand(promptsShowCmd)
+	promptsCmd.AddCommand(promptsEditCmd)
+	promptsShowCmd.Flags().Bool("default", false, "Show the built-in template even when it is overridden")
+	promptsEditCmd.Flags().Bool("repo", false, "Edit the repository's .tt/prompts copy instead of ~/.tt/prompts")
+}
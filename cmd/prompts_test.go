@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuiltinPromptsRender(t *testing.T) {
+	data := PromptData{Diff: "diff --git a/x b/x", Files: []string{"x"}, Message: "feat: x", Commits: "- abc x\n"}
+	for _, prompt := range promptTemplates {
+		content, err := builtinPrompts.ReadFile("prompts/" + prompt.Name + ".tmpl")
+		if err != nil {
+			t.Errorf("%s has no built-in template: %v", prompt.Name, err)
+			continue
+		}
+		tmpl, err := parsePrompt(prompt.Name, string(content))
+		if err != nil {
+			t.Errorf("%s does not parse: %v", prompt.Name, err)
+			continue
+		}
+		var sb strings.Builder
+		if err := tmpl.Execute(&sb, data); err != nil {
+			t.Errorf("%s does not render: %v", prompt.Name, err)
+		}
+		if strings.Contains(sb.String(), "<no value>") {
+			t.Errorf("%s renders a missing variable", prompt.Name)
+		}
+	}
+}
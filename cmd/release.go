@@ -0,0 +1,351 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/huh"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/aixoio/tt/styles"
+)
+
+// Semver is a parsed semantic version tag such as v1.4.2
+type Semver struct {
+	Prefix string
+	Major  int
+	Minor  int
+	Patch  int
+}
+
+// ReleaseCommit is a commit included in a release
+type ReleaseCommit struct {
+	Hash         string
+	Conventional bool
+	ConventionalCommit
+}
+
+var semverTagRe = regexp.MustCompile(`^(v?)(\d+)\.(\d+)\.(\d+)$`)
+
+var releaseCmd = &cobra.Command{
+	Use:       "release [major|minor|patch|auto]",
+	Aliases:   []string{"rel"},
+	Short:     "Bump the version, update the changelog and tag a release",
+	Long:      styles.Info.Render("Find the latest semver tag, work out the next version from Conventional Commits (or an explicit bump), update CHANGELOG.md and create an annotated tag."),
+	Args:      cobra.MatchAll(cobra.MaximumNArgs(1), cobra.OnlyValidArgs),
+	ValidArgs: []string{"major", "minor", "patch", "auto"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		bump := "auto"
+		if len(args) > 0 {
+			bump = args[0]
+		}
+		push, _ := cmd.Flags().GetBool("push")
+		noChangelog, _ := cmd.Flags().GetBool("no-changelog")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		// Show header
+		fmt.Println(styles.Header.Render("Release"))
+		fmt.Println()
+
+		// Check if we're in a git repository
+		if _, err := exec.Command("git", "rev-parse", "--git-dir").Output(); err != nil {
+			fmt.Println(styles.ErrorIcon + " " + styles.Error.Render("Not a git repository"))
+			return fmt.Errorf("not a git repository")
+		}
+
+		latestTag, current, err := latestSemverTag()
+		if err != nil {
+			return err
+		}
+		if latestTag == "" {
+			fmt.Println(styles.InfoIcon + " " + styles.Info.Render("No semver tags found, starting from ") + styles.Branch.Render(current.String()))
+		} else {
+			fmt.Println(styles.InfoIcon + " " + styles.Info.Render("Latest release: ") + styles.Branch.Render(latestTag))
+		}
+
+		commits, err := getReleaseCommits(latestTag)
+		if err != nil {
+			return err
+		}
+		if len(commits) == 0 {
+			fmt.Println(styles.SuccessIcon + " " + styles.Success.Render("Nothing to release, no commits since ") + styles.Branch.Render(latestTag))
+			return nil
+		}
+
+		if bump == "auto" {
+			bump = detectBump(commits)
+		}
+		next := current.Bump(bump)
+		tagName := next.String()
+
+		date := time.Now().Format("2006-01-02")
+		section := buildChangelogSection(tagName, date, commits)
+
+		fmt.Println()
+		fmt.Println(styles.Card.Render(
+			styles.Info.Render("Release details:") + "\n" +
+				styles.Neutral.Render("Version: ") + styles.Branch.Render(current.String()) + " → " + styles.Branch.Render(tagName) + "\n" +
+				styles.Neutral.Render("Bump: ") + styles.Highlight.Render(bump) + "\n" +
+				styles.Neutral.Render("Commits: ") + styles.Highlight.Render(strconv.Itoa(len(commits))) + "\n\n" +
+				styles.Muted.Render(section),
+		))
+
+		if dryRun {
+			fmt.Println(styles.InfoIcon + " " + styles.Info.Render("Dry run – no changes were made."))
+			return nil
+		}
+
+		// Confirm release
+		var confirm bool
+		prompt := huh.NewConfirm().
+			Title(styles.Primary.Render("Create release " + tagName + "?")).
+			Description("This will update CHANGELOG.md, commit it and create an annotated tag.").
+			Value(&confirm).
+			Affirmative("Yes, release").
+			Negative("No, cancel").
+			WithTheme(huh.ThemeCharm())
+
+		if err := prompt.Run(); err != nil {
+			return fmt.Errorf("failed to show confirmation prompt: %w", err)
+		}
+
+		if !confirm {
+			fmt.Println(styles.InfoIcon + " " + styles.Info.Render("Release cancelled"))
+			return nil
+		}
+
+		if !noChangelog {
+			if err := commitChangelog(tagName, section); err != nil {
+				return err
+			}
+		}
+
+		// Create annotated tag with the changelog section as its message
+		fmt.Print(styles.Spinner.Render("⏳") + " " + styles.Info.Render("Creating annotated tag... "))
+		tagCmd := exec.Command("git", withSigning(viper.GetBool("sign_tags"), "-s", "tag", "-a", tagName, "-m", "Release "+tagName+"\n\n"+section)...)
+		if output, err := tagCmd.CombinedOutput(); err != nil {
+			fmt.Println(styles.ErrorIcon)
+			return fmt.Errorf("failed to create tag: %v", string(output))
+		}
+		fmt.Println(styles.SuccessIcon)
+
+		fmt.Println()
+		fmt.Println(styles.Card.Render(
+			styles.Success.Render("Release created successfully!") + "\n" +
+				styles.Neutral.Render("Tag: ") + styles.Branch.Render(tagName),
+		))
+
+		if push {
+			fmt.Println(styles.InfoIcon + " " + styles.Info.Render("Pushing release commit... "))
+			if err := pushChanges(); err != nil {
+				return fmt.Errorf("failed to push release commit: %w", err)
+			}
+			fmt.Println()
+			return pushSpecificTag(tagName)
+		}
+
+		return nil
+	},
+}
+
+// parseSemver parses a tag like v1.2.3 or 1.2.3
+func parseSemver(tag string) (Semver, bool) {
+	matches := semverTagRe.FindStringSubmatch(strings.TrimSpace(tag))
+	if matches == nil {
+		return Semver{}, false
+	}
+
+	major, _ := strconv.Atoi(matches[2])
+	minor, _ := strconv.Atoi(matches[3])
+	patch, _ := strconv.Atoi(matches[4])
+	return Semver{Prefix: matches[1], Major: major, Minor: minor, Patch: patch}, true
+}
+
+func (v Semver) String() string {
+	return fmt.Sprintf("%s%d.%d.%d", v.Prefix, v.Major, v.Minor, v.Patch)
+}
+
+// Bump returns the next version for the given bump kind (major, minor or patch)
+func (v Semver) Bump(kind string) Semver {
+	switch kind {
+	case "major":
+		return Semver{Prefix: v.Prefix, Major: v.Major + 1}
+	case "minor":
+		return Semver{Prefix: v.Prefix, Major: v.Major, Minor: v.Minor + 1}
+	default:
+		return Semver{Prefix: v.Prefix, Major: v.Major, Minor: v.Minor, Patch: v.Patch + 1}
+	}
+}
+
+// latestSemverTag returns the highest semver tag, or an empty name and v0.0.0 if there is none
+func latestSemverTag() (string, Semver, error) {
+	output, err := exec.Command("git", "tag", "-l", "--sort=-v:refname").Output()
+	if err != nil {
+		return "", Semver{}, fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	for tag := range strings.SplitSeq(strings.TrimSpace(string(output)), "\n") {
+		if version, ok := parseSemver(tag); ok {
+			return strings.TrimSpace(tag), version, nil
+		}
+	}
+
+	return "", Semver{Prefix: "v"}, nil
+}
+
+// getReleaseCommits returns the commits made since the given tag (or all commits if tag is empty)
+func getReleaseCommits(tag string) ([]ReleaseCommit, error) {
+	args := []string{"log", "--no-merges", "--format=%h%x1f%s%x1f%b%x1e"}
+	if tag != "" {
+		args = append(args, tag+"..HEAD")
+	}
+
+	output, err := exec.Command("git", args...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commits: %w", err)
+	}
+
+	var commits []ReleaseCommit
+	for record := range strings.SplitSeq(string(output), "\x1e") {
+		fields := strings.Split(strings.TrimSpace(record), "\x1f")
+		if len(fields) < 3 {
+			continue
+		}
+
+		parsed, ok := parseConventionalCommit(fields[1], fields[2])
+		if ok && parsed.Type == "chore" && parsed.Scope == "release" {
+			continue
+		}
+		commits = append(commits, ReleaseCommit{Hash: fields[0], Conventional: ok, ConventionalCommit: parsed})
+	}
+
+	return commits, nil
+}
+
+// detectBump works out the bump kind from the commit types
+func detectBump(commits []ReleaseCommit) string {
+	bump := "patch"
+	for _, c := range commits {
+		if c.Breaking {
+			return "major"
+		}
+		if c.Type == "feat" {
+			bump = "minor"
+		}
+	}
+	return bump
+}
+
+// buildChangelogSection renders the markdown changelog entry for a release
+func buildChangelogSection(version, date string, commits []ReleaseCommit) string {
+	groups := []struct {
+		title   string
+		entries []string
+	}{
+		{title: "⚠ Breaking Changes"},
+		{title: "Features"},
+		{title: "Bug Fixes"},
+		{title: "Performance Improvements"},
+		{title: "Other Changes"},
+	}
+
+	for _, c := range commits {
+		entry := "- "
+		if c.Scope != "" {
+			entry += "**" + c.Scope + ":** "
+		}
+		entry += c.Subject + " (" + c.Hash + ")"
+
+		index := 4
+		switch {
+		case c.Breaking:
+			index = 0
+		case c.Type == "feat":
+			index = 1
+		case c.Type == "fix":
+			index = 2
+		case c.Type == "perf":
+			index = 3
+		}
+		groups[index].entries = append(groups[index].entries, entry)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("## " + version + " - " + date + "\n")
+	for _, group := range groups {
+		if len(group.entries) == 0 {
+			continue
+		}
+		sb.WriteString("\n### " + group.title + "\n\n")
+		sb.WriteString(strings.Join(group.entries, "\n") + "\n")
+	}
+
+	return sb.String()
+}
+
+// insertChangelogSection places a new section above the previous releases in a changelog
+func insertChangelogSection(existing, section string) string {
+	if strings.TrimSpace(existing) == "" {
+		return "# Changelog\n\n" + section
+	}
+
+	lines := strings.Split(existing, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, "## ") {
+			before := strings.Join(lines[:i], "\n")
+			after := strings.Join(lines[i:], "\n")
+			return before + "\n" + section + "\n" + after
+		}
+	}
+
+	return strings.TrimRight(existing, "\n") + "\n\n" + section
+}
+
+// commitChangelog writes the new section to CHANGELOG.md and commits only that file
+func commitChangelog(version, section string) error {
+	topLevel, err := exec.Command("git", "rev-parse", "--show-toplevel").Output()
+	if err != nil {
+		return fmt.Errorf("failed to find repository root: %w", err)
+	}
+	path := filepath.Join(strings.TrimSpace(string(topLevel)), "CHANGELOG.md")
+
+	fmt.Print(styles.Spinner.Render("⏳") + " " + styles.Info.Render("Updating CHANGELOG.md... "))
+	existing, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		fmt.Println(styles.ErrorIcon)
+		return fmt.Errorf("failed to read changelog: %w", err)
+	}
+
+	if err := os.WriteFile(path, []byte(insertChangelogSection(string(existing), section)), 0644); err != nil {
+		fmt.Println(styles.ErrorIcon)
+		return fmt.Errorf("failed to write changelog: %w", err)
+	}
+
+	if output, err := exec.Command("git", "add", path).CombinedOutput(); err != nil {
+		fmt.Println(styles.ErrorIcon)
+		return fmt.Errorf("failed to stage changelog: %v", string(output))
+	}
+
+	commitArgs := withSigning(viper.GetBool("sign_commits"), "-S", "commit", "-m", "chore(release): "+version)
+	if output, err := exec.Command("git", append(commitArgs, "--", path)...).CombinedOutput(); err != nil {
+		fmt.Println(styles.ErrorIcon)
+		return fmt.Errorf("failed to commit changelog: %v", string(output))
+	}
+	fmt.Println(styles.SuccessIcon)
+
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(releaseCmd)
+	releaseCmd.Flags().BoolP("push", "p", false, "Push the release commit and tag to remote")
+	releaseCmd.Flags().Bool("no-changelog", false, "Do not update CHANGELOG.md")
+	releaseCmd.Flags().BoolP("dry-run", "d", false, "Show the next version and changelog without making changes")
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseSemver(t *testing.T) {
+	v, ok := parseSemver("v1.2.3")
+	if !ok {
+		t.Fatal("expected v1.2.3 to parse")
+	}
+	if v.Prefix != "v" || v.Major != 1 || v.Minor != 2 || v.Patch != 3 {
+		t.Errorf("unexpected version %+v", v)
+	}
+
+	if _, ok := parseSemver("release-1"); ok {
+		t.Error("expected release-1 not to parse")
+	}
+}
+
+func TestSemverBump(t *testing.T) {
+	v := Semver{Prefix: "v", Major: 1, Minor: 2, Patch: 3}
+
+	tests := map[string]string{
+		"major": "v2.0.0",
+		"minor": "v1.3.0",
+		"patch": "v1.2.4",
+	}
+	for kind, want := range tests {
+		if got := v.Bump(kind).String(); got != want {
+			t.Errorf("Bump(%q) = %s, want %s", kind, got, want)
+		}
+	}
+}
+
+func TestDetectBump(t *testing.T) {
+	parse := func(subject, body string) ReleaseCommit {
+		c, ok := parseConventionalCommit(subject, body)
+		return ReleaseCommit{Conventional: ok, ConventionalCommit: c}
+	}
+
+	if got := detectBump([]ReleaseCommit{parse("fix: typo", "")}); got != "patch" {
+		t.Errorf("expected patch, got %s", got)
+	}
+	if got := detectBump([]ReleaseCommit{parse("fix: typo", ""), parse("feat(log): filters", "")}); got != "minor" {
+		t.Errorf("expected minor, got %s", got)
+	}
+	if got := detectBump([]ReleaseCommit{parse("feat!: new config", "")}); got != "major" {
+		t.Errorf("expected major, got %s", got)
+	}
+	if got := detectBump([]ReleaseCommit{parse("refactor: api", "BREAKING CHANGE: renamed flags")}); got != "major" {
+		t.Errorf("expected major from footer, got %s", got)
+	}
+}
+
+func TestInsertChangelogSection(t *testing.T) {
+	section := "## v1.1.0 - 2024-01-02\n\n### Features\n\n- new thing (abc1234)\n"
+
+	created := insertChangelogSection("", section)
+	if !strings.HasPrefix(created, "# Changelog\n\n## v1.1.0") {
+		t.Errorf("unexpected new changelog:\n%s", created)
+	}
+
+	existing := "# Changelog\n\n## v1.0.0 - 2024-01-01\n\n- first\n"
+	updated := insertChangelogSection(existing, section)
+	if strings.Index(updated, "v1.1.0") > strings.Index(updated, "v1.0.0") {
+		t.Errorf("expected new section above previous release:\n%s", updated)
+	}
+}
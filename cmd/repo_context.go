@@ -0,0 +1,279 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/spf13/viper"
+)
+
+// RepoContext describes the repository so prompts can match its languages,
+// layout and commit style
+type RepoContext struct {
+	Languages     []string
+	Packages      []string
+	Frameworks    []string
+	TestLayout    string
+	RecentCommits []string
+	Branch        string
+	Ticket        string
+	TicketURL     string
+}
+
+// languageExtensions maps source file extensions to language names
+var languageExtensions = map[string]string{
+	".go": "Go", ".ts": "TypeScript", ".tsx": "TypeScript", ".js": "JavaScript", ".jsx": "JavaScript",
+	".mjs": "JavaScript", ".cjs": "JavaScript", ".py": "Python", ".rs": "Rust", ".java": "Java",
+	".kt": "Kotlin", ".rb": "Ruby", ".php": "PHP", ".c": "C", ".h": "C", ".cc": "C++", ".cpp": "C++",
+	".hpp": "C++", ".cxx": "C++", ".cs": "C#", ".swift": "Swift", ".scala": "Scala", ".sh": "Shell",
+	".lua": "Lua", ".dart": "Dart", ".ex": "Elixir", ".exs": "Elixir", ".hs": "Haskell", ".zig": "Zig",
+	".vue": "Vue", ".svelte": "Svelte", ".sql": "SQL",
+}
+
+// packageManifests mark the root of a package in a monorepo
+var packageManifests = []string{"go.mod", "package.json", "Cargo.toml", "pyproject.toml", "setup.py", "pom.xml", "build.gradle", "build.gradle.kts", "CMakeLists.txt", "Gemfile", "composer.json", "mix.exs", "pubspec.yaml"}
+
+// frameworkMarkers are dependency names looked for in package manifests
+var frameworkMarkers = []struct {
+	Manifest string
+	Marker   string
+	Name     string
+}{
+	{"go.mod", "github.com/spf13/cobra", "Cobra"},
+	{"go.mod", "github.com/charmbracelet/bubbletea", "Bubble Tea"},
+	{"go.mod", "github.com/gin-gonic/gin", "Gin"},
+	{"go.mod", "github.com/labstack/echo", "Echo"},
+	{"go.mod", "github.com/gofiber/fiber", "Fiber"},
+	{"go.mod", "github.com/go-chi/chi", "chi"},
+	{"go.mod", "gorm.io/gorm", "GORM"},
+	{"go.mod", "github.com/stretchr/testify", "testify"},
+	{"package.json", `"next"`, "Next.js"},
+	{"package.json", `"react"`, "React"},
+	{"package.json", `"vue"`, "Vue"},
+	{"package.json", `"svelte"`, "Svelte"},
+	{"package.json", `"@angular/core"`, "Angular"},
+	{"package.json", `"express"`, "Express"},
+	{"package.json", `"@nestjs/core"`, "NestJS"},
+	{"package.json", `"jest"`, "Jest"},
+	{"package.json", `"vitest"`, "Vitest"},
+	{"pyproject.toml", "django", "Django"},
+	{"pyproject.toml", "flask", "Flask"},
+	{"pyproject.toml", "fastapi", "FastAPI"},
+	{"pyproject.toml", "pytest", "pytest"},
+	{"requirements.txt", "django", "Django"},
+	{"requirements.txt", "flask", "Flask"},
+	{"requirements.txt", "fastapi", "FastAPI"},
+	{"Cargo.toml", "tokio", "Tokio"},
+	{"Cargo.toml", "axum", "Axum"},
+	{"Cargo.toml", "actix-web", "Actix Web"},
+	{"Gemfile", "rails", "Rails"},
+	{"pom.xml", "spring-boot", "Spring Boot"},
+	{"build.gradle", "spring-boot", "Spring Boot"},
+}
+
+// repoContext collects the repository context once per run, since every
+// retry, candidate and prompt of a command would otherwise gather it again
+var repoContext = sync.OnceValue(collectRepoContext)
+
+// collectRepoContext gathers the enabled context pieces from the repository's
+// top level, so running tt from a subdirectory describes the whole project.
+// Pieces are added in order until context.max_bytes is used up.
+func collectRepoContext() RepoContext {
+	var ctx RepoContext
+	budget := viper.GetInt("context.max_bytes")
+	take := func(items []string) []string {
+		var kept []string
+		for _, item := range items {
+			if budget-len(item) < 0 {
+				break
+			}
+			budget -= len(item)
+			kept = append(kept, item)
+		}
+		return kept
+	}
+
+	// The ticket is always included; the branch name only when enabled
+	branch, _ := getCurrentBranch()
+	ticket := branchTicket(branch)
+	ctx.Ticket, ctx.TicketURL = ticket.Key, ticket.URL
+	budget -= len(ctx.Ticket) + len(ctx.TicketURL)
+	if viper.GetBool("context.branch") {
+		ctx.Branch = branch
+		budget -= len(ctx.Branch)
+	}
+
+	if viper.GetBool("context.commits") {
+		if output, err := exec.Command("git", "log", "-n", fmt.Sprint(viper.GetInt("context.commit_count")), "--no-merges", "--format=%s").Output(); err == nil && len(output) > 0 {
+			ctx.RecentCommits = take(strings.Split(strings.TrimSpace(string(output)), "\n"))
+		}
+	}
+
+	root, err := exec.Command("git", "rev-parse", "--show-toplevel").Output()
+	if err != nil {
+		return ctx
+	}
+	topLevel := strings.TrimSpace(string(root))
+	listCmd := exec.Command("git", "ls-files")
+	listCmd.Dir = topLevel
+	output, err := listCmd.Output()
+	if err != nil || len(output) == 0 {
+		return ctx
+	}
+	files := strings.Split(strings.TrimSpace(string(output)), "\n")
+
+	if viper.GetBool("context.languages") {
+		ctx.Languages = take(detectLanguages(files))
+	}
+	packages := detectPackages(files)
+	if viper.GetBool("context.packages") && len(packages) > 1 {
+		ctx.Packages = take(packages)
+	}
+	if viper.GetBool("context.frameworks") {
+		ctx.Frameworks = take(detectFrameworks(topLevel, files))
+	}
+	if viper.GetBool("context.tests") {
+		if layout := detectTestLayout(files); len(layout) <= budget {
+			ctx.TestLayout = layout
+			budget -= len(layout)
+		}
+	}
+
+	return ctx
+}
+
+// Summary describes the project in a few sentences for the ProjectInfo prompt variable
+func (c RepoContext) Summary() string {
+	var parts []string
+	if len(c.Languages) > 0 {
+		parts = append(parts, "Languages: "+strings.Join(c.Languages, ", ")+".")
+	}
+	if len(c.Packages) > 0 {
+		parts = append(parts, "Packages: "+strings.Join(c.Packages, ", ")+".")
+	}
+	if len(c.Frameworks) > 0 {
+		parts = append(parts, "Frameworks and libraries: "+strings.Join(c.Frameworks, ", ")+".")
+	}
+	if c.TestLayout != "" {
+		parts = append(parts, "Tests: "+c.TestLayout+".")
+	}
+	return strings.Join(parts, " ")
+}
+
+// detectLanguages lists the languages of tracked source files by share of files, largest first
+func detectLanguages(files []string) []string {
+	counts := map[string]int{}
+	total := 0
+	for _, file := range files {
+		if language, found := languageExtensions[strings.ToLower(path.Ext(file))]; found {
+			counts[language]++
+			total++
+		}
+	}
+
+	languages := make([]string, 0, len(counts))
+	for language := range counts {
+		languages = append(languages, language)
+	}
+	slices.SortFunc(languages, func(a, b string) int {
+		if counts[a] != counts[b] {
+			return counts[b] - counts[a]
+		}
+		return strings.Compare(a, b)
+	})
+
+	var result []string
+	for _, language := range languages {
+		share := counts[language] * 100 / total
+		if share < 2 && len(result) > 0 {
+			break
+		}
+		result = append(result, fmt.Sprintf("%s (%d%%)", language, share))
+	}
+	return result
+}
+
+// detectPackages lists directories holding a package manifest, "." for the top level
+func detectPackages(files []string) []string {
+	var packages []string
+	for _, file := range files {
+		if slices.Contains(packageManifests, path.Base(file)) && !isVendored(file) {
+			dir := path.Dir(file)
+			if !slices.Contains(packages, dir) {
+				packages = append(packages, dir)
+			}
+		}
+	}
+	slices.Sort(packages)
+	return packages
+}
+
+func isVendored(file string) bool {
+	return slices.ContainsFunc(strings.Split(file, "/"), func(dir string) bool {
+		return dir == "node_modules" || dir == "vendor" || dir == "testdata" || dir == "third_party"
+	})
+}
+
+// detectFrameworks reads the manifests of every package for well-known dependencies
+func detectFrameworks(topLevel string, files []string) []string {
+	var frameworks []string
+	for _, file := range files {
+		if isVendored(file) {
+			continue
+		}
+		base := path.Base(file)
+		var content string
+		for _, marker := range frameworkMarkers {
+			if marker.Manifest != base || slices.Contains(frameworks, marker.Name) {
+				continue
+			}
+			if content == "" {
+				data, err := os.ReadFile(filepath.Join(topLevel, filepath.FromSlash(file)))
+				if err != nil {
+					break
+				}
+				content = strings.ToLower(string(data))
+			}
+			if strings.Contains(content, strings.ToLower(marker.Marker)) {
+				frameworks = append(frameworks, marker.Name)
+			}
+		}
+	}
+	return frameworks
+}
+
+// detectTestLayout describes where tests live, using the same rules as the offline generator
+func detectTestLayout(files []string) string {
+	var layouts []string
+	add := func(layout string) {
+		if !slices.Contains(layouts, layout) {
+			layouts = append(layouts, layout)
+		}
+	}
+	for _, file := range files {
+		if !isTestFile(file) || isVendored(file) {
+			continue
+		}
+		base := path.Base(file)
+		dirs := strings.Split(path.Dir(file), "/")
+		switch {
+		case strings.HasSuffix(base, "_test.go"):
+			add("Go tests next to the code in *_test.go files")
+		case slices.Contains(dirs, "__tests__"):
+			add("__tests__ directories")
+		case strings.Contains(base, ".test.") || strings.Contains(base, ".spec."):
+			add("*.test/*.spec files next to the code")
+		case slices.Contains(dirs, "tests") || slices.Contains(dirs, "test"):
+			add("a separate tests/ directory")
+		case strings.HasPrefix(base, "test_") || strings.HasSuffix(base, "_test.py"):
+			add("pytest-style test_*.py files")
+		}
+	}
+	return strings.Join(layouts, ", ")
+}
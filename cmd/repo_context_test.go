@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDetectLanguages(t *testing.T) {
+	files := []string{"main.go", "cmd/a.go", "cmd/b.go", "web/app.ts", "README.md", "scripts/x.sh"}
+	got := detectLanguages(files)
+	want := []string{"Go (60%)", "Shell (20%)", "TypeScript (20%)"}
+	if !slices.Equal(got, want) {
+		t.Errorf("detectLanguages() = %v, want %v", got, want)
+	}
+}
+
+func TestDetectPackages(t *testing.T) {
+	files := []string{"go.mod", "tools/gen/go.mod", "web/package.json", "web/node_modules/x/package.json", "README.md"}
+	got := detectPackages(files)
+	want := []string{".", "tools/gen", "web"}
+	if !slices.Equal(got, want) {
+		t.Errorf("detectPackages() = %v, want %v", got, want)
+	}
+}
+
+func TestDetectTestLayout(t *testing.T) {
+	files := []string{"cmd/log.go", "cmd/log_test.go", "web/src/app.test.ts", "main.go"}
+	want := "Go tests next to the code in *_test.go files, *.test/*.spec files next to the code"
+	if got := detectTestLayout(files); got != want {
+		t.Errorf("detectTestLayout() = %q, want %q", got, want)
+	}
+	if got := detectTestLayout([]string{"main.go"}); got != "" {
+		t.Errorf("detectTestLayout() without tests = %q, want empty", got)
+	}
+}
@@ -109,7 +109,7 @@ var revertCmd = &cobra.Command{
 		}
 
 		// Perform the revert
-		return performRevert(targetCommit)
+		return performRevert(targetCommit, shouldSign(cmd, "sign_commits"))
 	},
 }
 
@@ -316,7 +316,7 @@ func getRevertDiffStats(hash string) (string, error) {
 	return strings.Join(formattedStats, "\n"), nil
 }
 
-func performRevert(hash string) error {
+func performRevert(hash string, sign bool) error {
 	fmt.Print(styles.SpinnerIcon + " " + styles.Info.Render("Preparing revert... "))
 
 	// First, try to revert without committing
@@ -355,7 +355,7 @@ func performRevert(hash string) error {
 
 	// Create revert commit message in format: "revert [hash]: [original message]"
 	revertMessage := fmt.Sprintf("revert %s: %s", hash, commitDetails.Message)
-	commitCmd := exec.Command("git", "commit", "-m", revertMessage)
+	commitCmd := exec.Command("git", withSigning(sign, "-S", "commit", "-m", revertMessage)...)
 	commitCmd.Stdout = os.Stdout
 	commitCmd.Stderr = os.Stderr
 
@@ -376,4 +376,5 @@ func performRevert(hash string) error {
 
 func init() {
 	rootCmd.AddCommand(revertCmd)
+	revertCmd.Flags().BoolP("sign", "S", false, "Sign the revert commit with GPG or SSH (overrides sign_commits from config)")
 }
@@ -0,0 +1,365 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/aixoio/tt/secrets"
+	"github.com/aixoio/tt/styles"
+)
+
+// ReviewFinding is one issue reported by the AI reviewer
+type ReviewFinding struct {
+	File       string `json:"file"`
+	Line       int    `json:"line"`
+	Severity   string `json:"severity"`
+	Category   string `json:"category"`
+	Message    string `json:"message"`
+	Suggestion string `json:"suggestion"`
+}
+
+// reviewSeverities lists the accepted severities from least to most severe
+var reviewSeverities = []string{"info", "low", "medium", "high", "critical"}
+
+var reviewCmd = &cobra.Command{
+	Use:   "review [range] [-- <path>...]",
+	Short: "Ask the AI for a code review of your changes",
+	Long:  styles.Info.Render("Send the diff (working tree by default, or a range such as main..HEAD) to the configured model acting as a reviewer, and show its findings ranked by severity next to the hunks they refer to."),
+	// Findings at or above --fail-on are a result, not a usage mistake
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		modelFlag, _ := cmd.Flags().GetString("model")
+		failOn, _ := cmd.Flags().GetString("fail-on")
+		jsonOutput, _ := cmd.Flags().GetBool("json")
+
+		if failOn != "" && severityRank(failOn) < 0 {
+			return fmt.Errorf("--fail-on must be one of: %s", strings.Join(reviewSeverities, ", "))
+		}
+
+		apiKey := viper.GetString("api_key")
+		if apiKey == "" {
+			fmt.Println(styles.ErrorIcon + " " + styles.Error.Render("API key not set. Run 'tt set' to configure it."))
+			return fmt.Errorf("API key not set")
+		}
+
+		if _, err := exec.Command("git", "rev-parse", "--git-dir").Output(); err != nil {
+			fmt.Println(styles.ErrorIcon + " " + styles.Error.Render("Not a git repository"))
+			return fmt.Errorf("not a git repository")
+		}
+
+		mode, err := diffModeFromFlags(cmd, args)
+		if err != nil {
+			return err
+		}
+		diffContent, err := mode.output("--no-color")
+		if err != nil {
+			return fmt.Errorf("failed to get git diff: %w", err)
+		}
+		if diffContent == "" {
+			if jsonOutput {
+				fmt.Println("[]")
+			} else {
+				fmt.Println(styles.SuccessIcon + " " + styles.Success.Render("No changes to review"))
+			}
+			return nil
+		}
+		files := parseUnifiedDiff(diffContent)
+
+		modelToUse := modelFlag
+		if modelToUse == "" {
+			modelToUse = viper.GetString("default_model")
+		}
+
+		if !jsonOutput {
+			fmt.Println(styles.Header.Render("Code Review"))
+			if description := mode.description(); description != "" {
+				fmt.Println(styles.Info.Render(description))
+			}
+			fmt.Println()
+			fmt.Printf("%s %s: %s\n\n", styles.InfoIcon, styles.Info.Render("Using model"), styles.Highlight.Render(modelToUse))
+		}
+
+		prompt, err := buildReviewPrompt(files, mode.description())
+		if err != nil {
+			return err
+		}
+
+		// JSON output is read by other programs, so it gets no spinner and
+		// anything else goes to stderr
+		review := func() (string, error) {
+			return generateAIResponse(apiKey, viper.GetString("base_url"), modelToUse, prompt)
+		}
+		var response string
+		if jsonOutput {
+			response, err = review()
+		} else {
+			response, err = runWithSpinnerForMessage("🔍 Reviewing changes...", review)
+		}
+		if err != nil {
+			printNotice(styles.ErrorIcon + " " + styles.Error.Render("Failed to generate review"))
+			return err
+		}
+
+		findings, warnings, err := parseReviewFindings(response)
+		if err != nil {
+			printNotice(styles.ErrorIcon + " " + styles.Error.Render("The model did not return valid review JSON"))
+			return err
+		}
+
+		if jsonOutput {
+			for _, warning := range warnings {
+				printNotice(styles.WarningIcon + " " + styles.Warning.Render(warning))
+			}
+			encoded, err := json.MarshalIndent(findings, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to encode findings: %w", err)
+			}
+			fmt.Println(string(encoded))
+		} else {
+			for _, warning := range warnings {
+				fmt.Println(styles.WarningIcon + " " + styles.Warning.Render(warning))
+			}
+			printReview(files, findings, syntaxHighlighterFromConfig())
+		}
+
+		if failOn != "" {
+			threshold := severityRank(failOn)
+			for _, finding := range findings {
+				if severityRank(finding.Severity) >= threshold {
+					return fmt.Errorf("review found issues of severity %s or higher", failOn)
+				}
+			}
+		}
+
+		return nil
+	},
+}
+
+// buildReviewPrompt asks for JSON findings about a diff whose lines are numbered
+// so the model can point at new-file line numbers
+func buildReviewPrompt(files []DiffFile, description string) (string, error) {
+	var sb strings.Builder
+	var paths []string
+	var denied []secrets.Finding
+	redactor := aiRedactor()
+	for _, file := range files {
+		paths = append(paths, file.Path())
+		sb.WriteString("File: " + file.Path() + "\n")
+		// Numbered lines hide the diff headers the redactor uses to spot deny-listed files
+		if redactor != nil && redactor.Denied(file.Path()) {
+			denied = append(denied, secrets.Finding{Path: file.Path(), Rule: "deny-listed path"})
+			sb.WriteString(secrets.Placeholder + " contents of " + file.Path() + "\n\n")
+			continue
+		}
+		for _, hunk := range file.Hunks {
+			sb.WriteString(hunk.Header + "\n")
+			newNo := hunk.NewStart
+			for _, line := range hunk.Lines {
+				switch {
+				case strings.HasPrefix(line, "-"), strings.HasPrefix(line, "\\"):
+					sb.WriteString("      " + line + "\n")
+				default:
+					sb.WriteString(fmt.Sprintf("%5d %s\n", newNo, line))
+					newNo++
+				}
+			}
+		}
+		sb.WriteString("\n")
+	}
+
+	printRedactions(denied)
+
+	data := newPromptData(sb.String(), paths)
+	data.Description = description
+	return renderPrompt("review", data)
+}
+
+// parseReviewFindings validates the model's JSON. Findings without a file or
+// message, or with an unknown severity, are dropped and reported as warnings.
+func parseReviewFindings(response string) ([]ReviewFinding, []string, error) {
+	body := strings.TrimSpace(response)
+	// Models sometimes wrap JSON in a fenced block despite being asked not to
+	if start, end := strings.Index(body, "{"), strings.LastIndex(body, "}"); start >= 0 && end > start {
+		body = body[start : end+1]
+	}
+
+	var payload struct {
+		Findings *[]ReviewFinding `json:"findings"`
+	}
+	if err := json.Unmarshal([]byte(body), &payload); err != nil {
+		return nil, nil, fmt.Errorf("invalid review JSON: %w", err)
+	}
+	if payload.Findings == nil {
+		return nil, nil, fmt.Errorf("invalid review JSON: missing \"findings\" array")
+	}
+
+	findings := []ReviewFinding{}
+	var warnings []string
+	for i, finding := range *payload.Findings {
+		finding.Severity = strings.ToLower(strings.TrimSpace(finding.Severity))
+		finding.Category = strings.ToLower(strings.TrimSpace(finding.Category))
+		switch {
+		case finding.File == "" || finding.Message == "":
+			warnings = append(warnings, fmt.Sprintf("Skipped finding %d: missing file or message", i+1))
+		case severityRank(finding.Severity) < 0:
+			warnings = append(warnings, fmt.Sprintf("Skipped finding %d: unknown severity %q", i+1, finding.Severity))
+		default:
+			findings = append(findings, finding)
+		}
+	}
+
+	// Most severe first, then in file order
+	slices.SortStableFunc(findings, func(a, b ReviewFinding) int {
+		if rank := severityRank(b.Severity) - severityRank(a.Severity); rank != 0 {
+			return rank
+		}
+		if a.File != b.File {
+			return strings.Compare(a.File, b.File)
+		}
+		return a.Line - b.Line
+	})
+
+	return findings, warnings, nil
+}
+
+// severityRank orders severities, returning -1 for unknown ones
+func severityRank(severity string) int {
+	return slices.Index(reviewSeverities, strings.ToLower(severity))
+}
+
+func severityStyle(severity string) string {
+	label := strings.ToUpper(severity)
+	switch severity {
+	case "critical", "high":
+		return styles.Error.Bold(true).Render(label)
+	case "medium":
+		return styles.Warning.Render(label)
+	case "low":
+		return styles.Info.Render(label)
+	default:
+		return styles.Muted.Render(label)
+	}
+}
+
+// printReview prints a severity summary, then each file's findings under the hunk they point at
+func printReview(files []DiffFile, findings []ReviewFinding, syntax *syntaxHighlighter) {
+	if len(findings) == 0 {
+		fmt.Println(styles.SuccessIcon + " " + styles.Success.Render("No issues found"))
+		return
+	}
+
+	var summary []string
+	for i := len(reviewSeverities) - 1; i >= 0; i-- {
+		count := 0
+		for _, finding := range findings {
+			if finding.Severity == reviewSeverities[i] {
+				count++
+			}
+		}
+		if count > 0 {
+			summary = append(summary, severityStyle(reviewSeverities[i])+" "+strconv.Itoa(count))
+		}
+	}
+	fmt.Println(styles.Primary.Render(fmt.Sprintf("%d findings: ", len(findings))) + strings.Join(summary, "  "))
+	fmt.Println()
+
+	shown := make([]bool, len(findings))
+	for _, file := range files {
+		var fileFindings []int
+		for i, finding := range findings {
+			if finding.File == file.Path() {
+				fileFindings = append(fileFindings, i)
+			}
+		}
+		if len(fileFindings) == 0 {
+			continue
+		}
+
+		fmt.Println(styles.FilePath.Render(file.Path()))
+		for _, hunk := range file.Hunks {
+			start, end := hunk.NewStart, hunk.NewStart+max(hunkNewLength(hunk)-1, 0)
+			var inHunk []int
+			for _, i := range fileFindings {
+				if !shown[i] && findings[i].Line >= start && findings[i].Line <= end {
+					inHunk = append(inHunk, i)
+				}
+			}
+			if len(inHunk) == 0 {
+				continue
+			}
+
+			fmt.Println(styles.DiffHeader.Render(hunk.Header))
+			fmt.Println(strings.Join(renderHunkUnified(hunk, file.Path(), diffRenderOptions{Syntax: syntax}), "\n"))
+			for _, i := range inHunk {
+				fmt.Println(formatFinding(findings[i]))
+				shown[i] = true
+			}
+		}
+
+		// Findings about lines outside the changed hunks
+		for _, i := range fileFindings {
+			if !shown[i] {
+				fmt.Println(formatFinding(findings[i]))
+				shown[i] = true
+			}
+		}
+		fmt.Println()
+	}
+
+	// Findings that name a file not in the diff
+	var others []string
+	for i, finding := range findings {
+		if !shown[i] {
+			others = append(others, formatFinding(finding))
+		}
+	}
+	if len(others) > 0 {
+		fmt.Println(styles.Muted.Render("Other files"))
+		fmt.Println(strings.Join(others, "\n"))
+	}
+}
+
+// hunkNewLength counts the lines a hunk occupies in the new file
+func hunkNewLength(hunk DiffHunk) int {
+	length := 0
+	for _, line := range hunk.Lines {
+		if !strings.HasPrefix(line, "-") && !strings.HasPrefix(line, "\\") {
+			length++
+		}
+	}
+	return length
+}
+
+func formatFinding(finding ReviewFinding) string {
+	location := finding.File
+	if finding.Line > 0 {
+		location += ":" + strconv.Itoa(finding.Line)
+	}
+
+	var details strings.Builder
+	details.WriteString(severityStyle(finding.Severity) + " " + styles.Muted.Render(finding.Category) + " " + styles.FilePath.Render(location) + "\n")
+	details.WriteString(styles.Primary.Render(finding.Message))
+	if finding.Suggestion != "" {
+		details.WriteString("\n" + styles.Success.Render("→ ") + styles.Neutral.Render(finding.Suggestion))
+	}
+	return styles.Card.Render(details.String())
+}
+
+func init() {
+	rootCmd.AddCommand(reviewCmd)
+	reviewCmd.Flags().StringP("model", "m", "", "Model to use for the review (overrides default_model from config)")
+	reviewCmd.Flags().String("fail-on", "", "Exit with an error when a finding has this severity or higher (info, low, medium, high, critical)")
+	reviewCmd.Flags().Bool("json", false, "Print the validated findings as JSON")
+	reviewCmd.Flags().Bool("staged", false, "Review staged changes instead of the working tree")
+	reviewCmd.Flags().String("commit", "", "Review the changes made by a single commit")
+	reviewCmd.Flags().String("branch", "", "Review what this branch adds since its merge-base with a base branch (default: the repository's default branch; use --branch=<base>)")
+	reviewCmd.Flags().Lookup("branch").NoOptDefVal = defaultBaseFlag
+	reviewCmd.MarkFlagsMutuallyExclusive("staged", "commit", "branch")
+}
@@ -28,6 +28,10 @@ Features:
 • ` + styles.Highlight.Render(`Conflict-aware`) + ` merge operations
 
 Get started by running: ` + styles.InlineCode.Render(`tt init`),
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		// Attribute AI usage in the ledger to the command that was run
+		aiCommand = cmd.CommandPath()
+	},
 	// Uncomment the following line if your bare application
 	// has an action associated with it:
 	// Run: func(cmd *cobra.Command, args []string) { },
@@ -66,6 +70,22 @@ func init() {
 	// Set defaults
 	viper.SetDefault("base_url", "https://openrouter.ai/api/v1")
 	viper.SetDefault("default_model", "google/gemini-2.5-flash-lite")
+	viper.SetDefault("diff_theme", defaultDiffTheme)
+	viper.SetDefault("pager", "internal")
+	viper.SetDefault("ai_cache", true)
+	viper.SetDefault("budget_action", "warn")
+	viper.SetDefault("redact.enabled", true)
+	viper.SetDefault("scan.max_binary_kb", 1024)
+	viper.SetDefault("message_editor", "inline")
+	viper.SetDefault("conventional_commits", true)
+	viper.SetDefault("tickets.mode", "none")
+	viper.SetDefault("tickets.prefix", "{{.Key}} ")
+	viper.SetDefault("tickets.trailer", "Refs")
+	viper.SetDefault("context.max_bytes", 2000)
+	viper.SetDefault("context.commit_count", 10)
+	for _, piece := range []string{"languages", "packages", "frameworks", "tests", "commits", "branch"} {
+		viper.SetDefault("context."+piece, true)
+	}
 
 	// Read config or create if not exists
 	if err := viper.ReadInConfig(); err != nil {
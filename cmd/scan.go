@@ -0,0 +1,287 @@
+package cmd
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/aixoio/tt/secrets"
+	"github.com/aixoio/tt/styles"
+)
+
+// secretsAllowFile lists paths and secret fingerprints the scanner should accept
+const secretsAllowFile = ".tt-secrets-allow"
+
+// secretsAllowlist holds the entries of .tt-secrets-allow: path globs and
+// sha256:<hex> fingerprints of individual secret values
+type secretsAllowlist struct {
+	Globs        []string
+	Fingerprints map[string]bool
+}
+
+// loadSecretsAllowlist reads .tt-secrets-allow from the repository's top level.
+// A missing file is an empty allowlist.
+func loadSecretsAllowlist() secretsAllowlist {
+	allow := secretsAllowlist{Fingerprints: map[string]bool{}}
+	root, err := exec.Command("git", "rev-parse", "--show-toplevel").Output()
+	if err != nil {
+		return allow
+	}
+	file, err := os.Open(filepath.Join(strings.TrimSpace(string(root)), secretsAllowFile))
+	if err != nil {
+		return allow
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "" || strings.HasPrefix(line, "#"):
+		case strings.HasPrefix(line, "sha256:"):
+			allow.Fingerprints[strings.ToLower(strings.TrimPrefix(line, "sha256:"))] = true
+		default:
+			allow.Globs = append(allow.Globs, strings.TrimPrefix(line, "/"))
+		}
+	}
+	return allow
+}
+
+// Allows reports whether a finding's path matches a glob, or its secret a fingerprint
+func (a secretsAllowlist) Allows(finding secrets.Finding) bool {
+	if finding.Secret != "" && a.Fingerprints[secretFingerprint(finding.Secret)] {
+		return true
+	}
+	for _, glob := range a.Globs {
+		if strings.HasSuffix(glob, "/") && strings.HasPrefix(finding.Path, glob) {
+			return true
+		}
+		if matched, _ := path.Match(glob, finding.Path); matched {
+			return true
+		}
+		if matched, _ := path.Match(glob, path.Base(finding.Path)); matched && !strings.Contains(glob, "/") {
+			return true
+		}
+	}
+	return false
+}
+
+// secretFingerprint identifies a secret in the allowlist without storing it
+func secretFingerprint(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// scanRedactor builds the redactor with the same rules and deny list as AI
+// redaction. Scanning runs even when redaction of prompts is turned off.
+func scanRedactor() *secrets.Redactor {
+	redactor, err := secrets.New(viper.GetStringSlice("redact.patterns"), viper.GetStringSlice("redact.paths"))
+	if err != nil {
+		printNotice(styles.WarningIcon + " " + styles.Warning.Render("Ignoring redact config: "+err.Error()))
+		redactor, _ = secrets.New(nil, nil)
+	}
+	return redactor
+}
+
+// largeBinaries lists binary files in a numstat listing that are larger than
+// scan.max_binary_kb. object turns a path into the object name to size, such as
+// ":path" for the index or "sha:path" for a commit.
+func largeBinaries(numstat string, object func(string) string) []secrets.Finding {
+	limit := viper.GetInt64("scan.max_binary_kb") * 1024
+	if limit <= 0 {
+		return nil
+	}
+
+	var findings []secrets.Finding
+	for _, line := range strings.Split(numstat, "\n") {
+		// Binary files have "-" for both line counts
+		fields := strings.SplitN(line, "\t", 3)
+		if len(fields) != 3 || fields[0] != "-" || fields[1] != "-" || strings.Contains(fields[2], " => ") {
+			continue
+		}
+		output, err := exec.Command("git", "cat-file", "-s", object(fields[2])).Output()
+		if err != nil {
+			// Deleted files have no object to size
+			continue
+		}
+		size, err := strconv.ParseInt(strings.TrimSpace(string(output)), 10, 64)
+		if err != nil || size <= limit {
+			continue
+		}
+		findings = append(findings, secrets.Finding{Path: fields[2], Rule: "large binary file (" + formatSize(size) + ")"})
+	}
+	return findings
+}
+
+func formatSize(size int64) string {
+	switch {
+	case size >= 1024*1024:
+		return fmt.Sprintf("%.1f MB", float64(size)/(1024*1024))
+	case size >= 1024:
+		return fmt.Sprintf("%.1f KB", float64(size)/1024)
+	}
+	return fmt.Sprintf("%d B", size)
+}
+
+// filterAllowed drops the findings covered by .tt-secrets-allow
+func filterAllowed(findings []secrets.Finding, allow secretsAllowlist) []secrets.Finding {
+	var kept []secrets.Finding
+	for _, finding := range findings {
+		if !allow.Allows(finding) {
+			kept = append(kept, finding)
+		}
+	}
+	return kept
+}
+
+// scanStaged checks the staged changes for secrets, sensitive files and large binaries
+func scanStaged() ([]secrets.Finding, error) {
+	diff, err := exec.Command("git", "diff", "--cached", "--no-color", "--no-ext-diff").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read staged changes: %w", err)
+	}
+	findings := scanRedactor().Scan(string(diff))
+
+	numstat, err := exec.Command("git", "diff", "--cached", "--numstat").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list staged files: %w", err)
+	}
+	findings = append(findings, largeBinaries(string(numstat), func(file string) string { return ":" + file })...)
+
+	return filterAllowed(findings, loadSecretsAllowlist()), nil
+}
+
+// scanCommit checks what a commit added, like scanStaged does for the index.
+// The redactor is built once by the caller and shared by every commit in a range.
+func scanCommit(sha string, redactor *secrets.Redactor) ([]secrets.Finding, error) {
+	diff, err := exec.Command("git", "show", "--format=", "--no-color", "--no-ext-diff", sha).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read commit %s: %w", sha, err)
+	}
+	findings := redactor.Scan(string(diff))
+
+	numstat, err := exec.Command("git", "show", "--format=", "--numstat", sha).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files of commit %s: %w", sha, err)
+	}
+	findings = append(findings, largeBinaries(string(numstat), func(file string) string { return sha + ":" + file })...)
+	return findings, nil
+}
+
+// renderScanFindings lists findings with the fingerprint to allowlist each secret
+func renderScanFindings(findings []secrets.Finding) string {
+	var lines []string
+	for _, finding := range findings {
+		line := "• " + styles.FilePath.Render(finding.Location()) + " " + styles.Neutral.Render(finding.Rule)
+		if masked := finding.Masked(); masked != "" {
+			line += " " + styles.Muted.Render(masked)
+		}
+		lines = append(lines, line)
+		if finding.Secret != "" {
+			lines = append(lines, "  "+styles.Muted.Render("sha256:"+secretFingerprint(finding.Secret)))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func printStagedFindings(findings []secrets.Finding) {
+	fmt.Println(styles.Card.Render(
+		styles.Warning.Render(fmt.Sprintf("Found %d possible secret(s) or large file(s) in the staged changes", len(findings))) + "\n\n" +
+			renderScanFindings(findings),
+	))
+}
+
+// checkStagedSecrets scans the index before a commit and blocks it when
+// anything is found, unless allow is set
+func checkStagedSecrets(allow bool) error {
+	findings, err := scanStaged()
+	if err != nil {
+		return err
+	}
+	if len(findings) == 0 {
+		return nil
+	}
+
+	printStagedFindings(findings)
+
+	if allow {
+		fmt.Println(styles.WarningIcon + " " + styles.Warning.Render("Committing anyway because --allow-secrets was given"))
+		return nil
+	}
+	fmt.Println(styles.InfoIcon + " " + styles.Info.Render("Unstage them, add the path or fingerprint to "+secretsAllowFile+", or pass --allow-secrets"))
+	return fmt.Errorf("commit blocked by the secret scanner")
+}
+
+var scanCmd = &cobra.Command{
+	Use:   "scan [range]",
+	Short: "Scan staged changes or commit history for secrets and large binaries",
+	Long:  styles.Info.Render("Check the staged changes, or every commit in a range such as main..HEAD, for credentials, sensitive files and large binary files. Paths and secret fingerprints listed in .tt-secrets-allow are skipped. Exits with an error when anything is found."),
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		fmt.Println(styles.Header.Render("Secret Scan"))
+		fmt.Println()
+
+		if len(args) == 0 {
+			findings, err := scanStaged()
+			if err != nil {
+				return err
+			}
+			if len(findings) == 0 {
+				fmt.Println(styles.SuccessIcon + " " + styles.Success.Render("Nothing found in the staged changes"))
+				return nil
+			}
+			printStagedFindings(findings)
+			return fmt.Errorf("secret scan found %d issue(s)", len(findings))
+		}
+
+		output, err := exec.Command("git", "rev-list", "--no-merges", args[0]).Output()
+		if err != nil {
+			fmt.Println(styles.ErrorIcon + " " + styles.Error.Render("Invalid range: "+args[0]))
+			return fmt.Errorf("invalid range %s: %w", args[0], err)
+		}
+		commits := strings.Fields(string(output))
+		allow := loadSecretsAllowlist()
+		redactor := scanRedactor()
+
+		total, affected := 0, 0
+		for _, sha := range commits {
+			findings, err := scanCommit(sha, redactor)
+			if err != nil {
+				return err
+			}
+			findings = filterAllowed(findings, allow)
+			if len(findings) == 0 {
+				continue
+			}
+			subject, _ := exec.Command("git", "log", "-1", "--format=%s", sha).Output()
+			fmt.Println(styles.Card.Render(
+				styles.CommitHash.Render(sha[:7]) + " " + styles.Neutral.Render(strings.TrimSpace(string(subject))) + "\n\n" +
+					renderScanFindings(findings),
+			))
+			total += len(findings)
+			affected++
+		}
+
+		if total == 0 {
+			fmt.Println(styles.SuccessIcon + " " + styles.Success.Render(fmt.Sprintf("Nothing found in %d commit(s)", len(commits))))
+			return nil
+		}
+		fmt.Println(styles.WarningIcon + " " + styles.Warning.Render(fmt.Sprintf("Found %d issue(s) in %d of %d commit(s)", total, affected, len(commits))))
+		fmt.Println(styles.InfoIcon + " " + styles.Info.Render("Secrets already in history should be rotated; rewriting history alone does not revoke them"))
+		return fmt.Errorf("secret scan found %d issue(s)", total)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(scanCmd)
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/aixoio/tt/styles"
+)
+
+// shouldSign reports whether to sign, preferring an explicit --sign flag over the config key
+func shouldSign(cmd *cobra.Command, configKey string) bool {
+	if flag := cmd.Flags().Lookup("sign"); flag != nil && flag.Changed {
+		sign, _ := cmd.Flags().GetBool("sign")
+		return sign
+	}
+	return viper.GetBool(configKey)
+}
+
+// withSigning builds git arguments that apply the configured signing format and key.
+// When sign is true, signFlag (-S for commits, -s for tags) is appended.
+func withSigning(sign bool, signFlag string, args ...string) []string {
+	var full []string
+	if format := viper.GetString("signing_format"); format != "" {
+		full = append(full, "-c", "gpg.format="+format)
+	}
+	if key := viper.GetString("signing_key"); key != "" {
+		full = append(full, "-c", "user.signingkey="+key)
+	}
+	full = append(full, args...)
+	if sign {
+		full = append(full, signFlag)
+	}
+	return full
+}
+
+// signatureBadge renders a %G? signature status code as a styled badge
+func signatureBadge(code string) string {
+	switch code {
+	case "G":
+		return styles.Success.Render("✓ verified")
+	case "U", "X", "Y", "E":
+		return styles.Warning.Render("? unverified")
+	case "B", "R":
+		return styles.Error.Render("✗ bad signature")
+	default:
+		return styles.Muted.Render("unsigned")
+	}
+}
+
+// tagSignatureStatus returns a %G?-style status code for a tag.
+// Lightweight tags cannot carry a signature and are reported as unsigned.
+func tagSignatureStatus(name string) string {
+	typeOutput, err := exec.Command("git", "cat-file", "-t", "refs/tags/"+name).Output()
+	if err != nil || strings.TrimSpace(string(typeOutput)) != "tag" {
+		return "N"
+	}
+
+	content, err := exec.Command("git", "cat-file", "tag", "refs/tags/"+name).Output()
+	if err != nil || !strings.Contains(string(content), "-----BEGIN") {
+		return "N"
+	}
+	return verifyTagStatus(name)
+}
+
+// tagSignatureFields is a for-each-ref format giving a tag's object type and
+// "signed" when it carries a signature, so a whole tag list is checked in one call
+const tagSignatureFields = "%(objecttype)%09%(if)%(contents:signature)%(then)signed%(end)"
+
+// verifyTagStatus runs verify-tag on a tag already known to be signed
+func verifyTagStatus(name string) string {
+	output, err := exec.Command("git", withSigning(false, "", "verify-tag", name)...).CombinedOutput()
+	if err == nil {
+		return "G"
+	}
+	if strings.Contains(strings.ToLower(string(output)), "bad signature") {
+		return "B"
+	}
+	return "E"
+}
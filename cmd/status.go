@@ -24,6 +24,9 @@ var statusCmd = &cobra.Command{
 		if branchOutput, err := branchCmd.Output(); err == nil {
 			currentBranch := strings.TrimSpace(string(branchOutput))
 			fmt.Println(styles.InfoIcon + " " + styles.Info.Render("Current branch: ") + styles.Branch.Render(currentBranch))
+			if ticket := branchTicket(currentBranch); ticket.Key != "" {
+				fmt.Println(styles.InfoIcon + " " + styles.Info.Render("Ticket: ") + renderTicket(ticket))
+			}
 			fmt.Println()
 		}
 
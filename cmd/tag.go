@@ -41,27 +41,29 @@ func listTags() error {
 
 	// Get all tags
 	fmt.Println(styles.Neutral.Render("Tags:"))
-	tagsCmd := exec.Command("git", "tag", "-l", "--sort=-creatordate", "--format=%(refname:short)|%(creatordate:relative)|%(subject)")
+	tagsCmd := exec.Command("git", "for-each-ref", "--sort=-creatordate", "--format=%(refname:short)%09%(creatordate:relative)%09"+tagSignatureFields+"%09%(subject)", "refs/tags")
 	if tagsOutput, err := tagsCmd.Output(); err == nil {
 		tags := strings.TrimSpace(string(tagsOutput))
 		if tags != "" {
 			for tagLine := range strings.SplitSeq(tags, "\n") {
-				tagLine = strings.TrimSpace(tagLine)
-				if tagLine != "" {
-					parts := strings.Split(tagLine, "|")
-					if len(parts) >= 2 {
-						tagName := parts[0]
-						tagDate := parts[1]
-						tagMessage := ""
-						if len(parts) > 2 {
-							tagMessage = parts[2]
-						}
-
-						fmt.Printf("  • %s (%s)\n", styles.Branch.Render(tagName), styles.Muted.Render(tagDate))
-						if tagMessage != "" {
-							fmt.Printf("    └─ %s\n", styles.Info.Render(tagMessage))
-						}
-					}
+				parts := strings.SplitN(tagLine, "\t", 5)
+				if len(parts) < 4 {
+					continue
+				}
+				tagName, tagDate, tagMessage := parts[0], parts[1], ""
+				if len(parts) > 4 {
+					tagMessage = parts[4]
+				}
+
+				// Only signed annotated tags need the slower verify-tag
+				status := "N"
+				if parts[2] == "tag" && parts[3] == "signed" {
+					status = verifyTagStatus(tagName)
+				}
+
+				fmt.Printf("  • %s (%s) %s\n", styles.Branch.Render(tagName), styles.Muted.Render(tagDate), signatureBadge(status))
+				if tagMessage != "" {
+					fmt.Printf("    └─ %s\n", styles.Info.Render(tagMessage))
 				}
 			}
 		} else {
@@ -76,6 +78,20 @@ func listTags() error {
 
 func createTagInteractive(name string, cmd *cobra.Command) error {
 	message, _ := cmd.Flags().GetString("message")
+	return createTag(name, "", message, shouldSign(cmd, "sign_tags"))
+}
+
+// createTag creates a lightweight or annotated tag at target (HEAD when empty)
+func createTag(name, target, message string, sign bool) error {
+	var targetArgs []string
+	if target != "" {
+		targetArgs = []string{target}
+	}
+
+	// Signed tags must be annotated, so fall back to the tag name as the message
+	if sign && message == "" {
+		message = name
+	}
 
 	// If no message provided, create lightweight tag
 	if message == "" {
@@ -84,7 +100,7 @@ func createTagInteractive(name string, cmd *cobra.Command) error {
 		fmt.Printf("%s %s\n", styles.InfoIcon, styles.Info.Render("Creating lightweight tag: "+styles.Branch.Render(name)))
 
 		fmt.Print(styles.Spinner.Render("⏳") + " " + styles.Info.Render("Creating tag... "))
-		tagCmd := exec.Command("git", "tag", name)
+		tagCmd := exec.Command("git", append([]string{"tag", name}, targetArgs...)...)
 		if output, err := tagCmd.CombinedOutput(); err != nil {
 			fmt.Println(styles.ErrorIcon)
 			return fmt.Errorf("failed to create tag: %v", string(output))
@@ -105,7 +121,7 @@ func createTagInteractive(name string, cmd *cobra.Command) error {
 	fmt.Printf("%s %s\n", styles.InfoIcon, styles.Info.Render("Creating annotated tag: "+styles.Branch.Render(name)))
 
 	fmt.Print(styles.Spinner.Render("⏳") + " " + styles.Info.Render("Creating annotated tag... "))
-	tagCmd := exec.Command("git", "tag", "-a", name, "-m", message)
+	tagCmd := exec.Command("git", append(withSigning(sign, "-s", "tag", "-a", name, "-m", message), targetArgs...)...)
 	if output, err := tagCmd.CombinedOutput(); err != nil {
 		fmt.Println(styles.ErrorIcon)
 		return fmt.Errorf("failed to create annotated tag: %v", string(output))
@@ -116,7 +132,8 @@ func createTagInteractive(name string, cmd *cobra.Command) error {
 	fmt.Println(styles.Card.Render(
 		styles.Success.Render("Annotated tag created successfully!") + "\n" +
 			styles.Neutral.Render("Tag: ") + styles.Branch.Render(name) + "\n" +
-			styles.Neutral.Render("Message: ") + styles.Highlight.Render(message),
+			styles.Neutral.Render("Message: ") + styles.Highlight.Render(message) + "\n" +
+			styles.Neutral.Render("Signature: ") + signatureBadge(tagSignatureStatus(name)),
 	))
 
 	return nil
@@ -177,4 +194,5 @@ func deleteTag(name string) error {
 func init() {
 	rootCmd.AddCommand(tagCmd)
 	tagCmd.Flags().StringP("message", "m", "", "Tag annotation message")
+	tagCmd.Flags().BoolP("sign", "s", false, "Sign the tag with GPG or SSH (overrides sign_tags from config)")
 }
@@ -0,0 +1,228 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/spf13/viper"
+
+	"github.com/aixoio/tt/styles"
+)
+
+// TicketRule finds an issue-tracker ticket in a branch name. Key and URL are
+// templates over the match: .Match is the first group, or the whole match
+// when the pattern has none, and URL may also use .Key.
+type TicketRule struct {
+	Pattern string `mapstructure:"pattern"`
+	Key     string `mapstructure:"key"`
+	URL     string `mapstructure:"url"`
+}
+
+// Ticket is the issue a branch is for
+type Ticket struct {
+	Key string
+	URL string
+}
+
+// defaultTicketRule finds a Jira-style key such as ABC-123 when no rules are configured
+var defaultTicketRule = TicketRule{Pattern: `[A-Z][A-Z0-9]+-\d+`}
+
+// ticketRules reads tickets.rules from the config, falling back to the default rule
+func ticketRules() []TicketRule {
+	var rules []TicketRule
+	if err := viper.UnmarshalKey("tickets.rules", &rules); err != nil || len(rules) == 0 {
+		return []TicketRule{defaultTicketRule}
+	}
+	return rules
+}
+
+// compiledTicketRule is a TicketRule with its pattern and templates parsed
+type compiledTicketRule struct {
+	pattern *regexp.Regexp
+	key     *template.Template
+	url     *template.Template
+}
+
+// ticketMatcher applies compiled rules, so many branches can be checked
+// without compiling the rules for each one
+type ticketMatcher []compiledTicketRule
+
+// compileTicketRules parses the patterns and templates of the rules
+func compileTicketRules(rules []TicketRule) (ticketMatcher, error) {
+	matcher := make(ticketMatcher, len(rules))
+	for i, rule := range rules {
+		pattern, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ticket pattern %q: %w", rule.Pattern, err)
+		}
+		matcher[i].pattern = pattern
+		if rule.Key != "" {
+			if matcher[i].key, err = parseTicketTemplate(rule.Key); err != nil {
+				return nil, err
+			}
+		}
+		if rule.URL != "" {
+			if matcher[i].url, err = parseTicketTemplate(rule.URL); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return matcher, nil
+}
+
+// find applies the rules in order and returns the first ticket found
+func (m ticketMatcher) find(branch string) (Ticket, error) {
+	for _, rule := range m {
+		matches := rule.pattern.FindStringSubmatch(branch)
+		if matches == nil {
+			continue
+		}
+		data := struct{ Match, Key string }{Match: matches[0]}
+		if len(matches) > 1 {
+			data.Match = matches[1]
+		}
+
+		var err error
+		data.Key = data.Match
+		if rule.key != nil {
+			if data.Key, err = executeTicketTemplate(rule.key, data); err != nil {
+				return Ticket{}, err
+			}
+		}
+		ticket := Ticket{Key: data.Key}
+		if rule.url != nil {
+			if ticket.URL, err = executeTicketTemplate(rule.url, data); err != nil {
+				return Ticket{}, err
+			}
+		}
+		return ticket, nil
+	}
+	return Ticket{}, nil
+}
+
+func parseTicketTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New(text).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ticket template %q: %w", text, err)
+	}
+	return tmpl, nil
+}
+
+func executeTicketTemplate(tmpl *template.Template, data any) (string, error) {
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, data); err != nil {
+		return "", fmt.Errorf("invalid ticket template %q: %w", tmpl.Name(), err)
+	}
+	return sb.String(), nil
+}
+
+func renderTicketTemplate(text string, data any) (string, error) {
+	tmpl, err := parseTicketTemplate(text)
+	if err != nil {
+		return "", err
+	}
+	return executeTicketTemplate(tmpl, data)
+}
+
+func compileConfiguredTicketRules() (ticketMatcher, error) {
+	return compileTicketRules(ticketRules())
+}
+
+// configuredTicketRules compiles the configured rules once per run
+var configuredTicketRules = sync.OnceValues(compileConfiguredTicketRules)
+
+// ticketRulesWarning makes sure an invalid rule is reported only once per run
+var ticketRulesWarning sync.Once
+
+// configuredTicket finds the ticket for a branch with the configured rules
+func configuredTicket(branch string) (Ticket, error) {
+	matcher, err := configuredTicketRules()
+	if err != nil {
+		return Ticket{}, err
+	}
+	return matcher.find(branch)
+}
+
+// branchTicket finds the ticket for a branch with the configured rules. An
+// invalid rule is reported once and treated as no ticket.
+func branchTicket(branch string) Ticket {
+	ticket, err := configuredTicket(branch)
+	if err != nil {
+		ticketRulesWarning.Do(func() {
+			printNotice(styles.WarningIcon + " " + styles.Warning.Render("Ignoring ticket rules: "+err.Error()))
+		})
+		return Ticket{}
+	}
+	return ticket
+}
+
+// currentTicket is the ticket for the checked-out branch
+func currentTicket() Ticket {
+	branch, err := getCurrentBranch()
+	if err != nil {
+		return Ticket{}
+	}
+	return branchTicket(branch)
+}
+
+// renderTicket shows a ticket key with its link, if any
+func renderTicket(ticket Ticket) string {
+	text := styles.Highlight.Render(ticket.Key)
+	if ticket.URL != "" {
+		text += " " + styles.Muted.Render(ticket.URL)
+	}
+	return text
+}
+
+// withTicketPrefix puts the ticket key at the start of the subject, after the
+// conventional type and scope so tools that parse them keep working. Messages
+// that already mention the key are left alone.
+func withTicketPrefix(message string, ticket Ticket) string {
+	if ticket.Key == "" || strings.Contains(message, ticket.Key) {
+		return message
+	}
+	prefix, err := renderTicketTemplate(viper.GetString("tickets.prefix"), ticket)
+	if err != nil {
+		printNotice(styles.WarningIcon + " " + styles.Warning.Render("Ignoring tickets.prefix: "+err.Error()))
+		prefix = ticket.Key + " "
+	}
+
+	subject, body, hasBody := strings.Cut(message, "\n")
+	if matches := conventionalHeaderRe.FindStringSubmatchIndex(subject); matches != nil {
+		// matches[8] is where the description starts
+		subject = subject[:matches[8]] + prefix + subject[matches[8]:]
+	} else {
+		subject = prefix + subject
+	}
+	if hasBody {
+		return subject + "\n" + body
+	}
+	return subject
+}
+
+// decorateMessage applies the ticket mode and the trailers to a message before committing
+func decorateMessage(message string, coAuthors []string) (string, error) {
+	ticket := currentTicket()
+	var trailers []string
+	switch viper.GetString("tickets.mode") {
+	case "prefix":
+		message = withTicketPrefix(message, ticket)
+	case "trailer":
+		if ticket.Key != "" && !strings.Contains(message, ticket.Key) {
+			trailers = append(trailers, viper.GetString("tickets.trailer")+": "+ticket.Key)
+		}
+	}
+
+	configured, err := configuredTrailers(ticket)
+	if err != nil {
+		return "", err
+	}
+	trailers = append(trailers, configured...)
+	for _, coAuthor := range coAuthors {
+		trailers = append(trailers, "Co-authored-by: "+coAuthor)
+	}
+	return interpretTrailers(message, trailers)
+}
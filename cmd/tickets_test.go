@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+// useTicketRules configures ticket rules for a test, starting a fresh "run"
+// so they are compiled again and an invalid rule warns again
+func useTicketRules(t *testing.T, rules []map[string]any) {
+	reset := func() {
+		configuredTicketRules = sync.OnceValues(compileConfiguredTicketRules)
+		ticketRulesWarning = sync.Once{}
+	}
+	viper.Set("tickets.rules", rules)
+	reset()
+	t.Cleanup(func() {
+		viper.Set("tickets.rules", nil)
+		reset()
+	})
+}
+
+func TestBranchTicket(t *testing.T) {
+	useTicketRules(t, []map[string]any{
+		{"pattern": `[A-Z][A-Z0-9]+-\d+`, "url": "https://jira.example.com/browse/{{.Key}}"},
+		{"pattern": `^\w+/(\d+)-`, "key": "#{{.Match}}", "url": "https://github.com/acme/app/issues/{{.Match}}"},
+	})
+
+	tests := []struct {
+		branch string
+		want   Ticket
+	}{
+		{"feat/ABC-123-login", Ticket{Key: "ABC-123", URL: "https://jira.example.com/browse/ABC-123"}},
+		{"fix/42-crash-on-start", Ticket{Key: "#42", URL: "https://github.com/acme/app/issues/42"}},
+		{"main", Ticket{}},
+	}
+	for _, tt := range tests {
+		if got := branchTicket(tt.branch); got != tt.want {
+			t.Errorf("branchTicket(%q) = %+v, want %+v", tt.branch, got, tt.want)
+		}
+	}
+}
+
+func TestBranchTicketInvalidRule(t *testing.T) {
+	useTicketRules(t, []map[string]any{{"pattern": "("}})
+
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stderr := os.Stderr
+	os.Stderr = writer
+	for _, branch := range []string{"feat/ABC-1-a", "fix/ABC-2-b", "main"} {
+		if got := branchTicket(branch); got != (Ticket{}) {
+			t.Errorf("branchTicket(%q) = %+v, want no ticket with an invalid rule", branch, got)
+		}
+	}
+	os.Stderr = stderr
+	writer.Close()
+	output, _ := io.ReadAll(reader)
+
+	if count := strings.Count(string(output), "Ignoring ticket rules"); count != 1 {
+		t.Errorf("warned %d times, want once:\n%s", count, output)
+	}
+	if _, err := configuredTicket("feat/ABC-1-a"); err == nil {
+		t.Error("configuredTicket with an invalid rule should fail")
+	}
+}
+
+func TestWithTicketPrefix(t *testing.T) {
+	viper.Set("tickets.prefix", "{{.Key}} ")
+	t.Cleanup(func() { viper.Set("tickets.prefix", nil) })
+	ticket := Ticket{Key: "ABC-123"}
+
+	tests := []struct {
+		message string
+		want    string
+	}{
+		{"feat(auth): add login\n\nBody.", "feat(auth): ABC-123 add login\n\nBody."},
+		{"fix!: drop legacy tokens", "fix!: ABC-123 drop legacy tokens"},
+		{"Add login", "ABC-123 Add login"},
+		{"feat: ABC-123 add login", "feat: ABC-123 add login"},
+	}
+	for _, tt := range tests {
+		if got := withTicketPrefix(tt.message, ticket); got != tt.want {
+			t.Errorf("withTicketPrefix(%q) = %q, want %q", tt.message, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package cmd
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/charmbracelet/huh"
+	"github.com/spf13/viper"
+
+	"github.com/aixoio/tt/styles"
+)
+
+// TeamMember is an entry of the team roster used for co-author trailers
+type TeamMember struct {
+	Alias string `mapstructure:"alias"`
+	Name  string `mapstructure:"name"`
+	Email string `mapstructure:"email"`
+}
+
+// Ident formats the member the way git writes authors
+func (m TeamMember) Ident() string {
+	return m.Name + " <" + m.Email + ">"
+}
+
+// TrailerData holds the variables available to configured trailers
+type TrailerData struct {
+	User      string
+	Name      string
+	Email     string
+	Branch    string
+	Ticket    string
+	TicketURL string
+}
+
+// identRe matches a literal "Name <email>" given instead of a roster alias
+var identRe = regexp.MustCompile(`^[^<>]+ <[^<>@\s]+@[^<>\s]+>$`)
+
+func teamRoster() []TeamMember {
+	var roster []TeamMember
+	// A malformed roster is treated as empty rather than failing the commit
+	_ = viper.UnmarshalKey("team", &roster)
+	return roster
+}
+
+// resolvePair turns --pair aliases into "Name <email>" idents. A full ident
+// may be given instead of an alias for someone outside the roster.
+func resolvePair(aliases []string) ([]string, error) {
+	roster := teamRoster()
+	var idents []string
+	for _, alias := range aliases {
+		alias = strings.TrimSpace(alias)
+		index := slices.IndexFunc(roster, func(m TeamMember) bool { return strings.EqualFold(m.Alias, alias) })
+		switch {
+		case index >= 0:
+			idents = append(idents, roster[index].Ident())
+		case identRe.MatchString(alias):
+			idents = append(idents, alias)
+		default:
+			known := make([]string, len(roster))
+			for i, member := range roster {
+				known[i] = member.Alias
+			}
+			if len(known) == 0 {
+				return nil, fmt.Errorf("unknown co-author '%s': add a team roster to the config or pass \"Name <email>\"", alias)
+			}
+			return nil, fmt.Errorf("unknown co-author '%s', the team roster has: %s", alias, strings.Join(known, ", "))
+		}
+	}
+	return idents, nil
+}
+
+// recentAuthors lists the other people who authored recent commits
+func recentAuthors(limit int) []string {
+	output, err := exec.Command("git", "log", "-n", fmt.Sprint(limit), "--format=%aN <%aE>").Output()
+	if err != nil {
+		return nil
+	}
+	self := gitConfig("user.email")
+
+	var authors []string
+	for _, author := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		if author == "" || slices.Contains(authors, author) || (self != "" && strings.HasSuffix(author, "<"+self+">")) {
+			continue
+		}
+		authors = append(authors, author)
+	}
+	return authors
+}
+
+func gitConfig(key string) string {
+	output, err := exec.Command("git", "config", "--get", key).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// selectCoAuthors offers the roster followed by recent authors in a multi-select
+func selectCoAuthors(preselected []string) ([]string, error) {
+	var options []huh.Option[string]
+	var seen []string
+	for _, member := range teamRoster() {
+		options = append(options, huh.NewOption(member.Alias+"  "+styles.Muted.Render(member.Ident()), member.Ident()))
+		seen = append(seen, member.Ident())
+	}
+	for _, author := range recentAuthors(200) {
+		if !slices.Contains(seen, author) {
+			options = append(options, huh.NewOption(author, author))
+			seen = append(seen, author)
+		}
+	}
+	if len(options) == 0 {
+		fmt.Println(styles.InfoIcon + " " + styles.Info.Render("No team roster or other recent authors to choose from"))
+		return preselected, nil
+	}
+
+	selected := slices.Clone(preselected)
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewMultiSelect[string]().
+				Title(styles.Primary.Render("Co-authors")).
+				Description("Space to toggle, enter to confirm").
+				Options(options...).
+				Value(&selected),
+		),
+	).WithTheme(huh.ThemeCharm())
+	if err := form.Run(); err != nil {
+		return nil, fmt.Errorf("failed to select co-authors: %w", err)
+	}
+	return selected, nil
+}
+
+// resolveCoAuthors combines --pair aliases with the interactive picker when pick is set
+func resolveCoAuthors(pair []string, pick bool) ([]string, error) {
+	coAuthors, err := resolvePair(pair)
+	if err != nil {
+		return nil, err
+	}
+	if pick {
+		return selectCoAuthors(coAuthors)
+	}
+	return coAuthors, nil
+}
+
+// messageCoAuthors returns the Co-authored-by trailers of a commit message
+func messageCoAuthors(message string) []string {
+	parseCmd := exec.Command("git", "interpret-trailers", "--parse")
+	parseCmd.Stdin = strings.NewReader(message + "\n")
+	output, err := parseCmd.Output()
+	if err != nil {
+		return nil
+	}
+
+	var coAuthors []string
+	for _, line := range strings.Split(string(output), "\n") {
+		key, value, found := strings.Cut(line, ":")
+		if found && strings.EqualFold(strings.TrimSpace(key), "Co-authored-by") {
+			coAuthors = append(coAuthors, strings.TrimSpace(value))
+		}
+	}
+	return coAuthors
+}
+
+// configuredTrailers renders the trailers config, such as "Signed-off-by: {{.User}}".
+// Trailers whose value renders empty, like a ticket on a branch without one, are skipped.
+func configuredTrailers(ticket Ticket) ([]string, error) {
+	name, email := gitConfig("user.name"), gitConfig("user.email")
+	branch, _ := getCurrentBranch()
+	data := TrailerData{
+		User:      name + " <" + email + ">",
+		Name:      name,
+		Email:     email,
+		Branch:    branch,
+		Ticket:    ticket.Key,
+		TicketURL: ticket.URL,
+	}
+
+	var trailers []string
+	for _, text := range viper.GetStringSlice("trailers") {
+		tmpl, err := template.New("trailer").Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trailer %q: %w", text, err)
+		}
+		var sb strings.Builder
+		if err := tmpl.Execute(&sb, data); err != nil {
+			return nil, fmt.Errorf("invalid trailer %q: %w", text, err)
+		}
+		key, value, found := strings.Cut(sb.String(), ":")
+		if !found {
+			return nil, fmt.Errorf("invalid trailer %q: expected \"Key: value\"", text)
+		}
+		if strings.TrimSpace(value) == "" {
+			continue
+		}
+		trailers = append(trailers, strings.TrimSpace(key)+": "+strings.TrimSpace(value))
+	}
+	return trailers, nil
+}
+
+// interpretTrailers appends trailers to a message with git interpret-trailers,
+// so an existing trailer block is extended and trailers already present are
+// not repeated
+func interpretTrailers(message string, trailers []string) (string, error) {
+	if len(trailers) == 0 {
+		return message, nil
+	}
+
+	args := []string{"interpret-trailers", "--if-exists", "addIfDifferent"}
+	for _, trailer := range trailers {
+		args = append(args, "--trailer", trailer)
+	}
+	trailerCmd := exec.Command("git", args...)
+	trailerCmd.Stdin = strings.NewReader(message + "\n")
+	output, err := trailerCmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to add trailers: %w", err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestResolvePair(t *testing.T) {
+	viper.Set("team", []map[string]any{
+		{"alias": "ana", "name": "Ana Lopez", "email": "ana@example.com"},
+		{"alias": "bo", "name": "Bo Chen", "email": "bo@example.com"},
+	})
+	t.Cleanup(func() { viper.Set("team", nil) })
+
+	got, err := resolvePair([]string{"Ana", "Cy Park <cy@example.com>"})
+	if err != nil {
+		t.Fatalf("resolvePair() error = %v", err)
+	}
+	if want := []string{"Ana Lopez <ana@example.com>", "Cy Park <cy@example.com>"}; !slices.Equal(got, want) {
+		t.Errorf("resolvePair() = %v, want %v", got, want)
+	}
+
+	if _, err := resolvePair([]string{"dee"}); err == nil || !strings.Contains(err.Error(), "ana, bo") {
+		t.Errorf("resolvePair(unknown) error = %v, want it to list the roster", err)
+	}
+}
+
+func TestInterpretTrailers(t *testing.T) {
+	message := "feat: add pairing\n\nBody.\n\nCo-authored-by: Ana Lopez <ana@example.com>"
+	got, err := interpretTrailers(message, []string{
+		"Co-authored-by: Ana Lopez <ana@example.com>",
+		"Co-authored-by: Bo Chen <bo@example.com>",
+		"Refs: ABC-123",
+	})
+	if err != nil {
+		t.Fatalf("interpretTrailers() error = %v", err)
+	}
+
+	want := "feat: add pairing\n\nBody.\n\nCo-authored-by: Ana Lopez <ana@example.com>\nCo-authored-by: Bo Chen <bo@example.com>\nRefs: ABC-123"
+	if got != want {
+		t.Errorf("interpretTrailers() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestConfiguredTrailersSkipsEmpty(t *testing.T) {
+	viper.Set("trailers", []string{"Reviewed-by: Bo Chen <bo@example.com>", "Refs: {{if false}}x{{end}}"})
+	t.Cleanup(func() { viper.Set("trailers", nil) })
+
+	got, err := configuredTrailers(Ticket{})
+	if err != nil {
+		t.Fatalf("configuredTrailers(Ticket{}) error = %v", err)
+	}
+	if want := []string{"Reviewed-by: Bo Chen <bo@example.com>"}; !slices.Equal(got, want) {
+		t.Errorf("configuredTrailers(Ticket{}) = %v, want %v", got, want)
+	}
+}
+
+func TestMessageCoAuthors(t *testing.T) {
+	message := "feat: pair on login\n\nBody text.\n\nCo-authored-by: Ana Diaz <ana@example.com>\nRefs: ABC-1\nCo-authored-by: Bo Chen <bo@example.com>"
+	want := []string{"Ana Diaz <ana@example.com>", "Bo Chen <bo@example.com>"}
+	if got := messageCoAuthors(message); !slices.Equal(got, want) {
+		t.Errorf("messageCoAuthors() = %v, want %v", got, want)
+	}
+	if got := messageCoAuthors("fix: solo work"); len(got) != 0 {
+		t.Errorf("messageCoAuthors() without trailers = %v, want none", got)
+	}
+}
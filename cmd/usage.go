@@ -0,0 +1,215 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/aixoio/tt/styles"
+)
+
+// UsageEntry is one line of the usage ledger
+type UsageEntry struct {
+	Time             time.Time `json:"time"`
+	Command          string    `json:"command"`
+	Model            string    `json:"model"`
+	PromptTokens     int64     `json:"prompt_tokens"`
+	CompletionTokens int64     `json:"completion_tokens"`
+	Cost             float64   `json:"cost"`
+	Cached           bool      `json:"cached,omitempty"`
+}
+
+// usageTotals aggregates ledger entries for one row of the report
+type usageTotals struct {
+	Name     string
+	Requests int
+	Cached   int
+	Tokens   int64
+	Cost     float64
+}
+
+func usageLedgerPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".tt", "usage.jsonl"), nil
+}
+
+// recordUsage appends an entry to ~/.tt/usage.jsonl. Accounting must never
+// break the command that made the request, so failures are ignored.
+func recordUsage(entry UsageEntry) {
+	path, err := usageLedgerPath()
+	if err != nil {
+		return
+	}
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
+	}
+	if entry.Command == "" {
+		entry.Command = aiCommand
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+	file.Write(append(line, '\n'))
+}
+
+// readUsage loads the ledger, skipping lines it cannot parse
+func readUsage() ([]UsageEntry, error) {
+	path, err := usageLedgerPath()
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer file.Close()
+
+	var entries []UsageEntry
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry UsageEntry
+		if json.Unmarshal(scanner.Bytes(), &entry) == nil {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, scanner.Err()
+}
+
+// summarizeUsage groups entries by the key function, most expensive first
+func summarizeUsage(entries []UsageEntry, key func(UsageEntry) string) []usageTotals {
+	index := map[string]int{}
+	var totals []usageTotals
+	for _, entry := range entries {
+		name := key(entry)
+		i, found := index[name]
+		if !found {
+			i = len(totals)
+			index[name] = i
+			totals = append(totals, usageTotals{Name: name})
+		}
+		totals[i].Requests++
+		if entry.Cached {
+			totals[i].Cached++
+		}
+		totals[i].Tokens += entry.PromptTokens + entry.CompletionTokens
+		totals[i].Cost += entry.Cost
+	}
+
+	slices.SortStableFunc(totals, func(a, b usageTotals) int {
+		switch {
+		case a.Cost > b.Cost:
+			return -1
+		case a.Cost < b.Cost:
+			return 1
+		default:
+			return int(b.Tokens - a.Tokens)
+		}
+	})
+	return totals
+}
+
+func printUsageTable(title string, totals []usageTotals) {
+	fmt.Println(styles.Primary.Render(title))
+	fmt.Println(styles.Muted.Render(fmt.Sprintf("  %-40s %9s %8s %12s %10s", "", "requests", "cached", "tokens", "cost")))
+	for _, row := range totals {
+		fmt.Printf("  %s %9d %8d %12d %10s\n",
+			styles.Highlight.Render(fmt.Sprintf("%-40s", row.Name)), row.Requests, row.Cached, row.Tokens, fmt.Sprintf("$%.4f", row.Cost))
+	}
+	fmt.Println()
+}
+
+var usageCmd = &cobra.Command{
+	Use:   "usage",
+	Short: "Show AI token usage and spend",
+	Long:  styles.Info.Render("Report the tokens and cost of tt's AI requests per model, command and day from the local ledger in ~/.tt/usage.jsonl. Costs use the prices configured in model_prices."),
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		days, _ := cmd.Flags().GetInt("days")
+
+		// Show header
+		fmt.Println(styles.Header.Render("AI Usage"))
+		fmt.Println()
+
+		entries, err := readUsage()
+		if err != nil {
+			return fmt.Errorf("failed to read usage ledger: %w", err)
+		}
+
+		since := time.Now().AddDate(0, 0, -days)
+		var recent []UsageEntry
+		for _, entry := range entries {
+			if entry.Time.After(since) {
+				recent = append(recent, entry)
+			}
+		}
+		if len(recent) == 0 {
+			fmt.Println(styles.InfoIcon + " " + styles.Info.Render(fmt.Sprintf("No AI requests in the last %d days", days)))
+			return nil
+		}
+
+		total := summarizeUsage(recent, func(UsageEntry) string { return "total" })[0]
+		now := time.Now()
+		monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+		monthSpend := 0.0
+		for _, entry := range entries {
+			if !entry.Time.Before(monthStart) {
+				monthSpend += entry.Cost
+			}
+		}
+
+		summary := styles.Neutral.Render(fmt.Sprintf("Last %d days: ", days)) +
+			styles.Highlight.Render(fmt.Sprintf("%d requests (%d cached), %d tokens, $%.4f", total.Requests, total.Cached, total.Tokens, total.Cost)) + "\n" +
+			styles.Neutral.Render("This month: ") + styles.Highlight.Render(fmt.Sprintf("$%.4f", monthSpend))
+		if budget := viper.GetFloat64("monthly_budget"); budget > 0 {
+			budgetText := fmt.Sprintf(" of $%.2f budget (%s)", budget, viper.GetString("budget_action"))
+			if monthSpend >= budget {
+				summary += styles.Error.Render(budgetText)
+			} else {
+				summary += styles.Muted.Render(budgetText)
+			}
+		}
+		fmt.Println(styles.Card.Render(summary))
+		fmt.Println()
+
+		printUsageTable("By model", summarizeUsage(recent, func(e UsageEntry) string { return e.Model }))
+		printUsageTable("By command", summarizeUsage(recent, func(e UsageEntry) string { return e.Command }))
+
+		byDay := summarizeUsage(recent, func(e UsageEntry) string { return e.Time.Local().Format("2006-01-02") })
+		slices.SortFunc(byDay, func(a, b usageTotals) int { return strings.Compare(b.Name, a.Name) })
+		printUsageTable("By day", byDay)
+
+		if !viper.IsSet("model_prices") {
+			fmt.Println(styles.InfoIcon + " " + styles.Muted.Render("Add model_prices to ~/.tt/config.yaml to see costs, e.g. [{model: google/gemini-2.5-flash-lite, input: 0.10, output: 0.40}] in $ per million tokens"))
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(usageCmd)
+	usageCmd.Flags().IntP("days", "d", 30, "Number of days to report")
+}
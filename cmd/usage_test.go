@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"math"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestSummarizeUsage(t *testing.T) {
+	entries := []UsageEntry{
+		{Command: "tt aic", Model: "cheap", PromptTokens: 100, CompletionTokens: 10, Cost: 0.001},
+		{Command: "tt aic", Model: "cheap", Cached: true},
+		{Command: "tt review", Model: "smart", PromptTokens: 1000, CompletionTokens: 200, Cost: 0.05},
+	}
+
+	byModel := summarizeUsage(entries, func(e UsageEntry) string { return e.Model })
+	if len(byModel) != 2 || byModel[0].Name != "smart" {
+		t.Fatalf("summarizeUsage() = %+v, want smart first", byModel)
+	}
+	cheap := byModel[1]
+	if cheap.Requests != 2 || cheap.Cached != 1 || cheap.Tokens != 110 {
+		t.Errorf("cheap totals = %+v", cheap)
+	}
+}
+
+func TestModelCost(t *testing.T) {
+	viper.Set("model_prices", []map[string]any{{"model": "smart", "input": 3.0, "output": 15.0}})
+	t.Cleanup(func() { viper.Set("model_prices", nil) })
+
+	if got := modelCost("smart", 1_000_000, 100_000); math.Abs(got-4.5) > 1e-9 {
+		t.Errorf("modelCost() = %v, want 4.5", got)
+	}
+	if got := modelCost("unpriced", 1000, 1000); got != 0 {
+		t.Errorf("modelCost() for an unpriced model = %v, want 0", got)
+	}
+}
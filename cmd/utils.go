@@ -2,10 +2,23 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 	"sync"
 	"time"
+
+	"golang.org/x/term"
 )
 
+// printNotice writes a line to stderr, keeping stdout clean for output that
+// other programs read. On a terminal it clears the current line first, so a
+// notice raised while a spinner runs replaces the spinner frame.
+func printNotice(line string) {
+	if term.IsTerminal(int(os.Stderr.Fd())) {
+		fmt.Fprint(os.Stderr, "\r\033[K")
+	}
+	fmt.Fprintln(os.Stderr, line)
+}
+
 func runWithSpinner(title string, action func() error) error {
 	fmt.Print("\033[?25l") // hide cursor
 	stop := make(chan struct{})
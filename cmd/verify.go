@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aixoio/tt/styles"
+)
+
+var verifyCmd = &cobra.Command{
+	Use:   "verify [ref]",
+	Short: "Verify the signature of a commit or tag",
+	Long:  styles.Info.Render("Check the GPG or SSH signature of a commit or annotated tag (default: HEAD) and print the signer details."),
+	Args:  cobra.MaximumNArgs(1),
+	// A missing or bad signature is a result, not a usage mistake
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ref := "HEAD"
+		if len(args) > 0 {
+			ref = args[0]
+		}
+
+		// Show header
+		fmt.Println(styles.Header.Render("Verify Signature"))
+		fmt.Println()
+
+		typeOutput, err := exec.Command("git", "cat-file", "-t", ref).Output()
+		if err != nil {
+			return fmt.Errorf("unknown revision '%s'", ref)
+		}
+
+		if strings.TrimSpace(string(typeOutput)) == "tag" {
+			return verifyTag(ref)
+		}
+		return verifyCommit(ref)
+	},
+}
+
+func verifyCommit(ref string) error {
+	output, err := exec.Command("git", withSigning(false, "", "log", "-1", "--format=%G?%x00%GS%x00%GK%x00%GF%x00%GT%x00%h%x00%s", ref)...).Output()
+	if err != nil {
+		return fmt.Errorf("failed to read commit signature: %w", err)
+	}
+
+	fields := strings.Split(strings.TrimSpace(string(output)), "\x00")
+	if len(fields) < 7 {
+		return fmt.Errorf("invalid signature details format")
+	}
+	code := fields[0]
+
+	details := styles.CommitHash.Render(fields[5]) + " " + styles.Primary.Render(fields[6]) + "\n" +
+		styles.Neutral.Render("Status: ") + signatureBadge(code)
+	if code != "N" {
+		details += "\n" + signerDetail("Signer", fields[1]) +
+			"\n" + signerDetail("Key", fields[2]) +
+			"\n" + signerDetail("Fingerprint", fields[3]) +
+			"\n" + signerDetail("Trust", fields[4])
+	}
+	fmt.Println(styles.Card.Render(details))
+
+	return signatureResult(code)
+}
+
+func verifyTag(name string) error {
+	code := tagSignatureStatus(name)
+
+	details := styles.Neutral.Render("Tag: ") + styles.Branch.Render(name) + "\n" +
+		styles.Neutral.Render("Status: ") + signatureBadge(code)
+
+	if code != "N" {
+		// verify-tag prints the signer details on stderr
+		output, _ := exec.Command("git", withSigning(false, "", "verify-tag", "-v", name)...).CombinedOutput()
+		var signerLines []string
+		for line := range strings.SplitSeq(string(output), "\n") {
+			if strings.Contains(line, "signature") || strings.Contains(line, "using") || strings.Contains(line, "key") {
+				signerLines = append(signerLines, styles.Muted.Render(strings.TrimSpace(line)))
+			}
+		}
+		if len(signerLines) > 0 {
+			details += "\n\n" + strings.Join(signerLines, "\n")
+		}
+	}
+	fmt.Println(styles.Card.Render(details))
+
+	return signatureResult(code)
+}
+
+func signerDetail(label, value string) string {
+	if value == "" {
+		value = "unknown"
+	}
+	return styles.Neutral.Render(label+": ") + styles.Highlight.Render(value)
+}
+
+// signatureResult turns a status code into an error so verify can be used in scripts
+func signatureResult(code string) error {
+	switch code {
+	case "G", "U":
+		return nil
+	case "N":
+		return fmt.Errorf("not signed")
+	default:
+		return fmt.Errorf("signature could not be verified")
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(verifyCmd)
+}
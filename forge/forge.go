@@ -0,0 +1,233 @@
+package forge
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Forge is a code hosting service that can open pull requests.
+// GitHub, GitLab and Gitea are supported; GitLab calls them merge requests.
+type Forge interface {
+	// Name returns the forge type, e.g. "github"
+	Name() string
+	// CreatePullRequest opens a pull request and returns where it can be viewed
+	CreatePullRequest(ctx context.Context, pr PullRequest) (*PullRequestResult, error)
+}
+
+// PullRequest describes a pull request to open
+type PullRequest struct {
+	Title string
+	Body  string
+	// Head is the branch with the changes, Base the branch to merge into
+	Head  string
+	Base  string
+	Draft bool
+}
+
+// PullRequestResult is a pull request as created by the forge
+type PullRequestResult struct {
+	Number int
+	URL    string
+}
+
+// Remote identifies a repository on a forge
+type Remote struct {
+	// Host is the web host, e.g. github.com or gitlab.example.com:8443
+	Host string
+	// Path is the repository path without .git, e.g. owner/repo or group/subgroup/repo
+	Path string
+}
+
+// Owner returns everything but the last path element
+func (r Remote) Owner() string {
+	if index := strings.LastIndex(r.Path, "/"); index != -1 {
+		return r.Path[:index]
+	}
+	return ""
+}
+
+// Repo returns the last path element
+func (r Remote) Repo() string {
+	return r.Path[strings.LastIndex(r.Path, "/")+1:]
+}
+
+// Options configure a forge client
+type Options struct {
+	// Type forces the forge type instead of detecting it from the host
+	Type string
+	// APIURL overrides the API root derived from the host
+	APIURL string
+	Token  string
+	// HTTPClient is used for requests, http.DefaultClient with a timeout when nil
+	HTTPClient *http.Client
+}
+
+// ParseRemoteURL parses the HTTPS, SSH and scp-like URLs git accepts for a remote
+func ParseRemoteURL(raw string) (Remote, error) {
+	raw = strings.TrimSpace(raw)
+	var host, path string
+
+	if strings.Contains(raw, "://") {
+		parsed, err := url.Parse(raw)
+		if err != nil {
+			return Remote{}, fmt.Errorf("invalid remote URL %q: %w", raw, err)
+		}
+		host, path = parsed.Host, parsed.Path
+		// The SSH port is not the web port
+		if parsed.Scheme == "ssh" || parsed.Scheme == "git+ssh" {
+			host = parsed.Hostname()
+		}
+	} else if before, after, found := strings.Cut(raw, ":"); found && !strings.Contains(before, "/") {
+		// scp-like syntax: [user@]host:path
+		host, path = before, after
+		if index := strings.LastIndex(host, "@"); index != -1 {
+			host = host[index+1:]
+		}
+	} else {
+		return Remote{}, fmt.Errorf("unsupported remote URL %q", raw)
+	}
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	if host == "" || !strings.Contains(path, "/") {
+		return Remote{}, fmt.Errorf("remote URL %q does not name an owner and repository", raw)
+	}
+	return Remote{Host: host, Path: path}, nil
+}
+
+// DetectType guesses the forge type from a host name, returning "" when unknown
+func DetectType(host string) string {
+	host = strings.ToLower(host)
+	switch {
+	case host == "github.com" || strings.Contains(host, "github"):
+		return "github"
+	case host == "gitlab.com" || strings.Contains(host, "gitlab"):
+		return "gitlab"
+	case host == "codeberg.org" || strings.Contains(host, "gitea") || strings.Contains(host, "forgejo"):
+		return "gitea"
+	default:
+		return ""
+	}
+}
+
+// New returns a client for the repository at remote
+func New(remote Remote, opts Options) (Forge, error) {
+	forgeType := opts.Type
+	if forgeType == "" {
+		forgeType = DetectType(remote.Host)
+	}
+	if forgeType == "" {
+		return nil, fmt.Errorf("cannot tell which forge hosts %s, set forge_type to github, gitlab or gitea", remote.Host)
+	}
+	if opts.Token == "" {
+		return nil, fmt.Errorf("no %s token configured", forgeType)
+	}
+
+	httpClient := opts.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	c := client{http: httpClient, token: opts.Token, remote: remote}
+
+	switch forgeType {
+	case "github":
+		c.apiURL = apiURL(opts.APIURL, remote.Host, "github.com", "https://api.github.com", "/api/v3")
+		return &GitHub{c}, nil
+	case "gitlab":
+		c.apiURL = apiURL(opts.APIURL, remote.Host, "", "", "/api/v4")
+		return &GitLab{c}, nil
+	case "gitea":
+		c.apiURL = apiURL(opts.APIURL, remote.Host, "", "", "/api/v1")
+		return &Gitea{c}, nil
+	default:
+		return nil, fmt.Errorf("unknown forge type %q, use github, gitlab or gitea", forgeType)
+	}
+}
+
+// apiURL returns the override, the public API for the hosted service, or the
+// self-hosted API path on the remote's host
+func apiURL(override, host, publicHost, publicAPI, selfHostedPath string) string {
+	if override != "" {
+		return strings.TrimRight(override, "/")
+	}
+	if publicHost != "" && strings.EqualFold(host, publicHost) {
+		return publicAPI
+	}
+	return "https://" + host + selfHostedPath
+}
+
+// client holds what every forge client needs to make authenticated JSON requests
+type client struct {
+	http   *http.Client
+	apiURL string
+	token  string
+	remote Remote
+}
+
+// postJSON sends payload to path and decodes a successful response into result
+func (c client) postJSON(ctx context.Context, path string, headers map[string]string, payload, result any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.apiURL+path, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s: %s", resp.Status, errorMessage(respBody))
+	}
+
+	if err := json.Unmarshal(respBody, result); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// errorMessage pulls the human-readable message out of a forge error response
+func errorMessage(body []byte) string {
+	var payload struct {
+		Message any `json:"message"`
+		Errors  []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil || payload.Message == nil {
+		return strings.TrimSpace(string(body))
+	}
+
+	// GitLab sometimes returns a list or map of messages
+	message := fmt.Sprint(payload.Message)
+	if text, ok := payload.Message.(string); ok {
+		message = text
+	}
+	for _, detail := range payload.Errors {
+		if detail.Message != "" {
+			message += ": " + detail.Message
+		}
+	}
+	return message
+}
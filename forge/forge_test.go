@@ -0,0 +1,155 @@
+package forge
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestParseRemoteURL(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want Remote
+	}{
+		{"https://github.com/aixoio/tt.git", Remote{Host: "github.com", Path: "aixoio/tt"}},
+		{"https://github.com/aixoio/tt", Remote{Host: "github.com", Path: "aixoio/tt"}},
+		{"git@github.com:aixoio/tt.git", Remote{Host: "github.com", Path: "aixoio/tt"}},
+		{"ssh://git@gitlab.example.com:2222/group/sub/project.git", Remote{Host: "gitlab.example.com", Path: "group/sub/project"}},
+		{"https://gitea.local:3000/me/app/", Remote{Host: "gitea.local:3000", Path: "me/app"}},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseRemoteURL(tt.raw)
+		if err != nil {
+			t.Errorf("ParseRemoteURL(%q) error = %v", tt.raw, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseRemoteURL(%q) = %+v, want %+v", tt.raw, got, tt.want)
+		}
+	}
+
+	for _, raw := range []string{"", "/local/path/repo", "https://github.com/onlyowner"} {
+		if _, err := ParseRemoteURL(raw); err == nil {
+			t.Errorf("ParseRemoteURL(%q) expected an error", raw)
+		}
+	}
+}
+
+func TestRemoteOwnerRepo(t *testing.T) {
+	remote := Remote{Host: "gitlab.com", Path: "group/sub/project"}
+	if remote.Owner() != "group/sub" || remote.Repo() != "project" {
+		t.Errorf("Owner() = %q, Repo() = %q", remote.Owner(), remote.Repo())
+	}
+}
+
+func TestDetectType(t *testing.T) {
+	tests := map[string]string{
+		"github.com":           "github",
+		"github.example.com":   "github",
+		"gitlab.com":           "gitlab",
+		"codeberg.org":         "gitea",
+		"gitea.internal:3000":  "gitea",
+		"git.example.com":      "",
+		"bitbucket.org":        "",
+		"GitLab.Example.COM":   "gitlab",
+		"forgejo.example.com":  "gitea",
+		"source.gitlab.acme.x": "gitlab",
+	}
+	for host, want := range tests {
+		if got := DetectType(host); got != want {
+			t.Errorf("DetectType(%q) = %q, want %q", host, got, want)
+		}
+	}
+}
+
+// standIn records the request it receives and answers with a canned response
+func standIn(t *testing.T, status int, response string, got *http.Request, payload *map[string]any) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*got = *r.Clone(context.Background())
+		if err := json.NewDecoder(r.Body).Decode(payload); err != nil {
+			t.Errorf("request body is not JSON: %v", err)
+		}
+		w.WriteHeader(status)
+		w.Write([]byte(response))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestCreatePullRequest(t *testing.T) {
+	tests := []struct {
+		forgeType  string
+		path       string
+		response   string
+		authHeader string
+		authValue  string
+		wantTitle  string
+		wantNumber int
+	}{
+		{"github", "/repos/aixoio/tt/pulls", `{"number": 7, "html_url": "https://github.com/aixoio/tt/pull/7"}`, "Authorization", "Bearer secret", "Add forge", 7},
+		{"gitlab", "/projects/aixoio%2Ftt/merge_requests", `{"iid": 3, "web_url": "https://gitlab.com/aixoio/tt/-/merge_requests/3"}`, "Private-Token", "secret", "Draft: Add forge", 3},
+		{"gitea", "/repos/aixoio/tt/pulls", `{"number": 12, "html_url": "https://codeberg.org/aixoio/tt/pulls/12"}`, "Authorization", "token secret", "WIP: Add forge", 12},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.forgeType, func(t *testing.T) {
+			var got http.Request
+			var payload map[string]any
+			server := standIn(t, http.StatusCreated, tt.response, &got, &payload)
+
+			client, err := New(Remote{Host: "example.com", Path: "aixoio/tt"}, Options{Type: tt.forgeType, APIURL: server.URL, Token: "secret"})
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			pr := PullRequest{Title: "Add forge", Body: "Adds forge clients", Head: "feature", Base: "main", Draft: tt.forgeType != "github"}
+			result, err := client.CreatePullRequest(context.Background(), pr)
+			if err != nil {
+				t.Fatalf("CreatePullRequest() error = %v", err)
+			}
+
+			if got.Method != http.MethodPost || got.URL.EscapedPath() != tt.path {
+				t.Errorf("request = %s %s, want POST %s", got.Method, got.URL.EscapedPath(), tt.path)
+			}
+			if value := got.Header.Get(tt.authHeader); value != tt.authValue {
+				t.Errorf("%s header = %q, want %q", tt.authHeader, value, tt.authValue)
+			}
+			if payload["title"] != tt.wantTitle {
+				t.Errorf("title = %v, want %q", payload["title"], tt.wantTitle)
+			}
+			if result.Number != tt.wantNumber || !strings.HasPrefix(result.URL, "https://") {
+				t.Errorf("result = %+v", result)
+			}
+		})
+	}
+}
+
+func TestCreatePullRequestError(t *testing.T) {
+	var got http.Request
+	var payload map[string]any
+	server := standIn(t, http.StatusUnprocessableEntity, `{"message": "Validation Failed", "errors": [{"message": "A pull request already exists for aixoio:feature."}]}`, &got, &payload)
+
+	client, err := New(Remote{Host: "github.com", Path: "aixoio/tt"}, Options{APIURL: server.URL, Token: "secret"})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	_, err = client.CreatePullRequest(context.Background(), PullRequest{Title: "x", Head: "feature", Base: "main"})
+	if err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("CreatePullRequest() error = %v, want the forge's message", err)
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	if _, err := New(Remote{Host: "git.example.com", Path: "a/b"}, Options{Token: "x"}); err == nil {
+		t.Error("expected an error for an unknown host")
+	}
+	if _, err := New(Remote{Host: "github.com", Path: "a/b"}, Options{}); err == nil {
+		t.Error("expected an error without a token")
+	}
+}
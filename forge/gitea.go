@@ -0,0 +1,40 @@
+package forge
+
+import (
+	"context"
+	"fmt"
+)
+
+// Gitea opens pull requests through the Gitea REST API, which Forgejo and Codeberg share
+type Gitea struct {
+	client
+}
+
+func (g *Gitea) Name() string {
+	return "gitea"
+}
+
+func (g *Gitea) CreatePullRequest(ctx context.Context, pr PullRequest) (*PullRequestResult, error) {
+	title := pr.Title
+	if pr.Draft {
+		// Gitea treats a WIP: prefix as a draft
+		title = "WIP: " + title
+	}
+	payload := map[string]any{
+		"title": title,
+		"body":  pr.Body,
+		"head":  pr.Head,
+		"base":  pr.Base,
+	}
+	headers := map[string]string{"Authorization": "token " + g.token}
+
+	var result struct {
+		Number  int    `json:"number"`
+		HTMLURL string `json:"html_url"`
+	}
+	path := fmt.Sprintf("/repos/%s/%s/pulls", g.remote.Owner(), g.remote.Repo())
+	if err := g.postJSON(ctx, path, headers, payload, &result); err != nil {
+		return nil, fmt.Errorf("gitea: %w", err)
+	}
+	return &PullRequestResult{Number: result.Number, URL: result.HTMLURL}, nil
+}
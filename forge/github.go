@@ -0,0 +1,40 @@
+package forge
+
+import (
+	"context"
+	"fmt"
+)
+
+// GitHub opens pull requests through the GitHub REST API, including GitHub Enterprise
+type GitHub struct {
+	client
+}
+
+func (g *GitHub) Name() string {
+	return "github"
+}
+
+func (g *GitHub) CreatePullRequest(ctx context.Context, pr PullRequest) (*PullRequestResult, error) {
+	payload := map[string]any{
+		"title": pr.Title,
+		"body":  pr.Body,
+		"head":  pr.Head,
+		"base":  pr.Base,
+		"draft": pr.Draft,
+	}
+	headers := map[string]string{
+		"Authorization":        "Bearer " + g.token,
+		"Accept":               "application/vnd.github+json",
+		"X-GitHub-Api-Version": "2022-11-28",
+	}
+
+	var result struct {
+		Number  int    `json:"number"`
+		HTMLURL string `json:"html_url"`
+	}
+	path := fmt.Sprintf("/repos/%s/%s/pulls", g.remote.Owner(), g.remote.Repo())
+	if err := g.postJSON(ctx, path, headers, payload, &result); err != nil {
+		return nil, fmt.Errorf("github: %w", err)
+	}
+	return &PullRequestResult{Number: result.Number, URL: result.HTMLURL}, nil
+}
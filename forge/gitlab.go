@@ -0,0 +1,42 @@
+package forge
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// GitLab opens merge requests through the GitLab REST API
+type GitLab struct {
+	client
+}
+
+func (g *GitLab) Name() string {
+	return "gitlab"
+}
+
+func (g *GitLab) CreatePullRequest(ctx context.Context, pr PullRequest) (*PullRequestResult, error) {
+	title := pr.Title
+	if pr.Draft {
+		// GitLab marks drafts by title prefix
+		title = "Draft: " + title
+	}
+	payload := map[string]any{
+		"title":         title,
+		"description":   pr.Body,
+		"source_branch": pr.Head,
+		"target_branch": pr.Base,
+	}
+	headers := map[string]string{"PRIVATE-TOKEN": g.token}
+
+	var result struct {
+		IID    int    `json:"iid"`
+		WebURL string `json:"web_url"`
+	}
+	// Projects are addressed by their URL-encoded full path, subgroups included
+	path := "/projects/" + url.PathEscape(g.remote.Path) + "/merge_requests"
+	if err := g.postJSON(ctx, path, headers, payload, &result); err != nil {
+		return nil, fmt.Errorf("gitlab: %w", err)
+	}
+	return &PullRequestResult{Number: result.IID, URL: result.WebURL}, nil
+}
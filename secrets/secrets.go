@@ -0,0 +1,367 @@
+// Package secrets finds credentials and other sensitive data in git diffs
+// and text, and redacts them before the text leaves the machine.
+package secrets
+
+import (
+	"fmt"
+	"math"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Rule is a named pattern for one kind of secret. When the pattern has a
+// capture group, only the group is the secret; otherwise the whole match is.
+type Rule struct {
+	Name    string
+	Pattern *regexp.Regexp
+}
+
+// Finding is a secret found in a diff or text
+type Finding struct {
+	// Path and Line locate the secret in the new version of a file; both are
+	// empty outside a diff
+	Path   string
+	Line   int
+	Rule   string
+	Secret string
+}
+
+// Location is "path:line", "path" or "" depending on what is known
+func (f Finding) Location() string {
+	switch {
+	case f.Path != "" && f.Line > 0:
+		return f.Path + ":" + strconv.Itoa(f.Line)
+	default:
+		return f.Path
+	}
+}
+
+// Masked shows just enough of the secret to recognise it
+func (f Finding) Masked() string {
+	if f.Secret == "" {
+		return ""
+	}
+	runes := []rune(f.Secret)
+	if len(runes) <= 8 {
+		return strings.Repeat("*", len(runes))
+	}
+	return string(runes[:4]) + strings.Repeat("*", min(len(runes)-4, 12))
+}
+
+// Placeholder replaces redacted secrets
+const Placeholder = "[REDACTED]"
+
+// BuiltinRules detect common credential formats
+var BuiltinRules = []Rule{
+	{"AWS access key ID", regexp.MustCompile(`\b((?:AKIA|ASIA)[0-9A-Z]{16})\b`)},
+	{"AWS secret access key", regexp.MustCompile(`(?i)aws_?secret_?access_?key["']?\s*[:=]+\s*["']?([A-Za-z0-9/+=]{40})\b`)},
+	{"GitHub token", regexp.MustCompile(`\b(gh[pousr]_[A-Za-z0-9]{36,255})\b`)},
+	{"GitHub fine-grained token", regexp.MustCompile(`\b(github_pat_[A-Za-z0-9_]{22,255})\b`)},
+	{"GitLab token", regexp.MustCompile(`\b(glpat-[A-Za-z0-9_-]{20,})\b`)},
+	{"Slack token", regexp.MustCompile(`\b(xox[abposr]-[A-Za-z0-9-]{10,})\b`)},
+	{"Slack webhook", regexp.MustCompile(`(https://hooks\.slack\.com/services/[A-Za-z0-9/_-]+)`)},
+	{"Google API key", regexp.MustCompile(`\b(AIza[0-9A-Za-z_-]{35})\b`)},
+	{"OpenAI-style API key", regexp.MustCompile(`\b(sk-(?:or-v1-|proj-|ant-)?[A-Za-z0-9_-]{32,})\b`)},
+	{"Password or secret assignment", regexp.MustCompile(`(?i)(?:password|passwd|secret|token|api_?key|access_?key|auth_?key)\w*["']?\s*[:=]+\s*["']([^"'\s]{8,})["']`)},
+}
+
+// DefaultDenyPaths are files whose contents are never sent, whatever they contain
+var DefaultDenyPaths = []string{
+	".env", ".env.*", "*.env", "*.pem", "*.key", "*.p12", "*.pfx", "*.jks", "*.keystore",
+	"id_rsa", "id_rsa*", "id_dsa*", "id_ecdsa*", "id_ed25519*", ".netrc", ".npmrc", ".pypirc",
+	"*.tfvars", "credentials.json", "service-account*.json", "*.kdbx",
+}
+
+// lockFiles hold checksums that look random, so the entropy check skips them
+var lockFiles = []string{"go.sum", "package-lock.json", "yarn.lock", "pnpm-lock.yaml", "Cargo.lock", "poetry.lock", "Gemfile.lock", "composer.lock", "uv.lock"}
+
+var (
+	pemBeginRe     = regexp.MustCompile(`-----BEGIN [A-Z0-9 ]*PRIVATE KEY( BLOCK)?-----`)
+	pemEndRe       = regexp.MustCompile(`-----END [A-Z0-9 ]*PRIVATE KEY( BLOCK)?-----`)
+	entropyTokenRe = regexp.MustCompile(`[A-Za-z0-9+/_=-]{32,}`)
+	hunkHeaderRe   = regexp.MustCompile(`^@@ -\d+(?:,\d+)? \+(\d+)(?:,\d+)? @@`)
+	placeholderRe  = regexp.MustCompile(`^[\w.-]*(example|placeholder|changeme|xxxx|your[_-]|<|\$\{)`)
+)
+
+// minEntropy is the Shannon entropy, in bits per character, above which a long
+// mixed-case alphanumeric token is treated as a generated secret
+const minEntropy = 4.5
+
+// Redactor detects secrets with the built-in rules plus configured patterns and paths
+type Redactor struct {
+	rules     []Rule
+	denyPaths []string
+}
+
+// New builds a Redactor. Extra patterns are regular expressions (the first
+// capture group, if any, is the secret) and extra paths are globs matched
+// against the full path and the file name.
+func New(patterns []string, denyPaths []string) (*Redactor, error) {
+	r := &Redactor{
+		rules:     slices.Clone(BuiltinRules),
+		denyPaths: append(slices.Clone(DefaultDenyPaths), denyPaths...),
+	}
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction pattern %q: %w", pattern, err)
+		}
+		r.rules = append(r.rules, Rule{Name: "custom pattern", Pattern: re})
+	}
+	for _, glob := range r.denyPaths {
+		if _, err := path.Match(glob, ""); err != nil {
+			return nil, fmt.Errorf("invalid redaction path %q: %w", glob, err)
+		}
+	}
+	return r, nil
+}
+
+// Denied reports whether a file's contents must never be sent
+func (r *Redactor) Denied(file string) bool {
+	base := path.Base(file)
+	for _, glob := range r.denyPaths {
+		if matched, _ := path.Match(glob, file); matched {
+			return true
+		}
+		if matched, _ := path.Match(glob, base); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// Redact replaces secrets in text, which may be or contain git diffs, and
+// reports what was replaced. The contents of deny-listed files in a diff are
+// dropped entirely and reported as one finding with an empty Secret.
+func (r *Redactor) Redact(text string) (string, []Finding) {
+	var findings []Finding
+	var out strings.Builder
+	w := diffWalker{}
+	inPEM, denied := false, false
+
+	lines := strings.SplitAfter(text, "\n")
+	for _, raw := range lines {
+		line := strings.TrimSuffix(raw, "\n")
+		if w.header(line) {
+			denied = w.path != "" && r.Denied(w.path)
+			inPEM = false
+			out.WriteString(raw)
+			continue
+		}
+		lineNo := w.advance(line)
+
+		if denied {
+			if w.inHunk {
+				if !w.notedDenied {
+					findings = append(findings, Finding{Path: w.path, Rule: "deny-listed path"})
+					out.WriteString(fmt.Sprintf("%s contents of %s\n", Placeholder, w.path))
+					w.notedDenied = true
+				}
+				continue
+			}
+			// The diff has ended, so what follows is ordinary text
+			denied = false
+		}
+
+		prefix, content := splitDiffPrefix(line, w.inHunk)
+		switch {
+		case inPEM:
+			if pemEndRe.MatchString(content) {
+				inPEM = false
+				out.WriteString(raw)
+			}
+			continue
+		case pemBeginRe.MatchString(content):
+			inPEM = true
+			findings = append(findings, Finding{Path: w.path, Line: lineNo, Rule: "private key", Secret: pemBeginRe.FindString(content)})
+			out.WriteString(raw + prefix + Placeholder + "\n")
+			continue
+		}
+
+		redacted, lineFindings := r.redactLine(content, w.path)
+		for i := range lineFindings {
+			lineFindings[i].Path, lineFindings[i].Line = w.path, lineNo
+		}
+		findings = append(findings, lineFindings...)
+		if len(lineFindings) == 0 {
+			out.WriteString(raw)
+			continue
+		}
+		out.WriteString(prefix + redacted)
+		if strings.HasSuffix(raw, "\n") {
+			out.WriteString("\n")
+		}
+	}
+
+	return out.String(), findings
+}
+
+// Scan reports secrets on the lines a diff adds, and deny-listed files that
+// gain content, without changing anything. Removed lines are not reported.
+func (r *Redactor) Scan(diff string) []Finding {
+	var findings []Finding
+	w := diffWalker{}
+	inPEM := false
+
+	for _, line := range strings.Split(diff, "\n") {
+		if w.header(line) {
+			inPEM = false
+			continue
+		}
+		lineNo := w.advance(line)
+		if !w.inHunk || !strings.HasPrefix(line, "+") {
+			continue
+		}
+		content := line[1:]
+
+		switch {
+		case r.Denied(w.path):
+			if !w.notedDenied {
+				findings = append(findings, Finding{Path: w.path, Line: lineNo, Rule: "sensitive file"})
+				w.notedDenied = true
+			}
+		case inPEM:
+			inPEM = !pemEndRe.MatchString(content)
+		case pemBeginRe.MatchString(content):
+			inPEM = true
+			findings = append(findings, Finding{Path: w.path, Line: lineNo, Rule: "private key", Secret: pemBeginRe.FindString(content)})
+		default:
+			_, lineFindings := r.redactLine(content, w.path)
+			for i := range lineFindings {
+				lineFindings[i].Path, lineFindings[i].Line = w.path, lineNo
+			}
+			findings = append(findings, lineFindings...)
+		}
+	}
+
+	return findings
+}
+
+// redactLine applies the rules and the entropy check to one line of content
+func (r *Redactor) redactLine(content, file string) (string, []Finding) {
+	var findings []Finding
+	for _, rule := range r.rules {
+		content = rule.Pattern.ReplaceAllStringFunc(content, func(match string) string {
+			secret := match
+			if groups := rule.Pattern.FindStringSubmatch(match); len(groups) > 1 && groups[1] != "" {
+				secret = groups[1]
+			}
+			if placeholderRe.MatchString(strings.ToLower(secret)) || secret == Placeholder {
+				return match
+			}
+			findings = append(findings, Finding{Rule: rule.Name, Secret: secret})
+			return strings.Replace(match, secret, Placeholder, 1)
+		})
+	}
+
+	if !slices.Contains(lockFiles, path.Base(file)) {
+		content = entropyTokenRe.ReplaceAllStringFunc(content, func(token string) string {
+			if !looksGenerated(token) {
+				return token
+			}
+			findings = append(findings, Finding{Rule: "high-entropy string", Secret: token})
+			return Placeholder
+		})
+	}
+	return content, findings
+}
+
+// looksGenerated reports whether a token mixes cases and digits with high entropy,
+// as API keys and tokens do, unlike identifiers, hex hashes and words
+func looksGenerated(token string) bool {
+	var upper, lower, digit bool
+	for _, c := range token {
+		switch {
+		case c >= 'A' && c <= 'Z':
+			upper = true
+		case c >= 'a' && c <= 'z':
+			lower = true
+		case c >= '0' && c <= '9':
+			digit = true
+		}
+	}
+	return upper && lower && digit && Entropy(token) >= minEntropy
+}
+
+// Entropy is the Shannon entropy of s in bits per character
+func Entropy(s string) float64 {
+	if s == "" {
+		return 0
+	}
+	counts := map[rune]int{}
+	for _, c := range s {
+		counts[c]++
+	}
+	total := float64(len([]rune(s)))
+	entropy := 0.0
+	for _, n := range counts {
+		p := float64(n) / total
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
+// splitDiffPrefix separates the +, - or space that starts a diff line inside a hunk
+func splitDiffPrefix(line string, inHunk bool) (string, string) {
+	if inHunk && line != "" && strings.ContainsRune("+- ", rune(line[0])) {
+		return line[:1], line[1:]
+	}
+	return "", line
+}
+
+// diffWalker follows file headers and hunk line numbers through a diff
+type diffWalker struct {
+	path        string
+	inHunk      bool
+	nextLine    int
+	notedDenied bool
+}
+
+// header consumes file header lines, reporting whether line was one
+func (w *diffWalker) header(line string) bool {
+	switch {
+	case strings.HasPrefix(line, "diff --git "):
+		rest := strings.TrimPrefix(line, "diff --git ")
+		if i := strings.LastIndex(rest, " b/"); i != -1 {
+			w.path = rest[i+3:]
+		}
+		w.inHunk, w.notedDenied = false, false
+		return true
+	case !w.inHunk && strings.HasPrefix(line, "+++ b/"):
+		w.path = strings.TrimPrefix(line, "+++ b/")
+		return true
+	case !w.inHunk && w.path != "" && (strings.HasPrefix(line, "index ") || strings.HasPrefix(line, "--- ") ||
+		strings.HasPrefix(line, "+++ ") || strings.HasPrefix(line, "new file mode") || strings.HasPrefix(line, "deleted file mode") ||
+		strings.HasPrefix(line, "similarity index") || strings.HasPrefix(line, "rename ") || strings.HasPrefix(line, "Binary files")):
+		return true
+	}
+	return false
+}
+
+// advance consumes a hunk header or content line and returns its new-file line number, or 0
+func (w *diffWalker) advance(line string) int {
+	if matches := hunkHeaderRe.FindStringSubmatch(line); matches != nil {
+		w.inHunk = true
+		w.nextLine, _ = strconv.Atoi(matches[1])
+		return 0
+	}
+	if !w.inHunk {
+		return 0
+	}
+	switch {
+	case strings.HasPrefix(line, "-"):
+		return w.nextLine
+	case strings.HasPrefix(line, "+"), strings.HasPrefix(line, " "):
+		w.nextLine++
+		return w.nextLine - 1
+	case strings.HasPrefix(line, "\\"):
+		return 0
+	default:
+		// Anything else ends the diff, such as prompt text after it
+		w.inHunk = false
+		return 0
+	}
+}
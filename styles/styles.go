@@ -129,6 +129,26 @@ var (
 	Del = lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#EF4444"))
 
+	// Word-level diff highlights for the changed parts of a line
+	AddHighlight = Add.
+			Background(lipgloss.Color("#065F46")).
+			Bold(true)
+
+	DelHighlight = Del.
+			Background(lipgloss.Color("#7F1D1D")).
+			Bold(true)
+
+	// Background tints for syntax-highlighted added and removed lines
+	AddLine = lipgloss.NewStyle().
+		Background(lipgloss.Color("#0F2A1F"))
+
+	DelLine = lipgloss.NewStyle().
+		Background(lipgloss.Color("#2E1416"))
+
+	// Line numbers and column separators in side-by-side diffs
+	DiffGutter = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#6B7280"))
+
 	// Spinner icon
 	SpinnerIcon = "⏳"
 )